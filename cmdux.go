@@ -26,9 +26,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
+	"github.com/bagaking/cmdux/ux"
 )
 
 // App represents the main cmdux application context.
@@ -43,42 +45,99 @@ type App struct {
 type Config struct {
 	// Writer specifies where output should be written. Defaults to os.Stdout.
 	Writer io.Writer
-	
+
 	// Theme specifies the color theme to use. Defaults to DefaultTheme.
 	Theme *style.Theme
-	
+
 	// Width specifies the terminal width. If 0, will auto-detect.
 	Width int
-	
+
+	// Symbols provides the glyphs components draw with. Defaults to
+	// style.DefaultSymbols; style.ASCIISymbols suits terminals without
+	// Unicode support.
+	Symbols style.SymbolSet
+
 	// EnableColors enables or disables color output. Auto-detected by default.
 	EnableColors *bool
+
+	// Verbosity selects which of Normal, Verbose and Debug produce output.
+	// Defaults to VerbosityNormal.
+	Verbosity int
 }
 
+// Verbosity levels, from -q to -vv. Levels are ordered, so a CLI can
+// count its -v flags: VerbosityNormal + count.
+const (
+	// VerbosityQuiet silences Normal, Verbose and Debug output.
+	VerbosityQuiet = -1
+	// VerbosityNormal shows Normal output only. This is the default.
+	VerbosityNormal = 0
+	// VerbosityVerbose also shows Verbose output.
+	VerbosityVerbose = 1
+	// VerbosityDebug shows everything, including Debug output.
+	VerbosityDebug = 2
+)
+
 // New creates a new cmdux application with default settings.
 func New(options ...func(*Config)) *App {
 	config := &Config{
-		Writer: os.Stdout,
-		Theme:  style.DefaultTheme(),
+		Writer:  os.Stdout,
+		Theme:   style.DefaultTheme(),
+		Symbols: style.DefaultSymbols(),
 	}
-	
+
 	for _, option := range options {
 		option(config)
 	}
-	
-	return &App{
-		theme:  config.Theme,
+
+	app := &App{
 		writer: config.Writer,
 		config: config,
 	}
+	app.SetTheme(config.Theme)
+	return app
 }
 
-// WithTheme sets a custom theme for the application.
+// NewSilent creates an application that writes to io.Discard with colors
+// disabled, for libraries that take an *App but whose caller wants no UI,
+// e.g. for a --no-ui flag. The options apply first, so they cannot turn the
+// output back on. Spinners, progress bars, prompts and interactive tables
+// are silenced with ux.SetOutput, input.SetOutput and ui.SetOutput.
+func NewSilent(options ...func(*Config)) *App {
+	return New(append(options, WithWriter(io.Discard), WithColors(false))...)
+}
+
+// WithTheme sets a custom theme for the application. A nil theme selects
+// style.DefaultTheme.
 func WithTheme(theme *style.Theme) func(*Config) {
 	return func(c *Config) {
 		c.Theme = theme
 	}
 }
 
+// WithColors forces color output on or off instead of detecting it.
+func WithColors(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.EnableColors = &enabled
+	}
+}
+
+// WithSymbols sets the symbol set components draw with, e.g.
+// style.ASCIISymbols() for terminals without Unicode support.
+func WithSymbols(symbols style.SymbolSet) func(*Config) {
+	return func(c *Config) {
+		c.Symbols = symbols
+	}
+}
+
+// WithVerbosity sets the verbosity level, e.g. VerbosityQuiet for -q or
+// VerbosityVerbose for -v.
+func WithVerbosity(level int) func(*Config) {
+	return func(c *Config) {
+		c.Verbosity = level
+	}
+}
+
 // WithWriter sets a custom writer for output.
 func WithWriter(w io.Writer) func(*Config) {
 	return func(c *Config) {
@@ -91,9 +150,84 @@ func (a *App) Theme() *style.Theme {
 	return a.theme
 }
 
+// SetTheme switches the application to theme, e.g. when the user picks one
+// at runtime. Components keep no theme of their own, so everything rendered
+// afterwards uses the new colors. When colors were forced on or off with
+// WithColors or SetColors, the theme is adjusted to match. A nil theme
+// selects style.DefaultTheme.
+func (a *App) SetTheme(theme *style.Theme) {
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	a.config.Theme = theme
+	a.theme = theme
+	if a.config.EnableColors != nil {
+		a.theme = theme.WithColors(*a.config.EnableColors)
+	}
+}
+
+// SetColors forces color output on or off for the current and any later theme.
+func (a *App) SetColors(enabled bool) {
+	a.config.EnableColors = &enabled
+	a.SetTheme(a.config.Theme)
+}
+
+// Symbols returns the symbol set components are rendered with.
+func (a *App) Symbols() style.SymbolSet {
+	return a.config.Symbols
+}
+
+// SetSymbols switches the glyphs of everything rendered afterwards, e.g. to
+// style.ASCIISymbols() when the user asks for plain output. Prompts in the
+// input package have their own setting, input.SetSymbols.
+func (a *App) SetSymbols(symbols style.SymbolSet) {
+	a.config.Symbols = symbols
+}
+
+// RenderContext returns the context components are rendered under: the
+// app's theme and symbols and the configured width.
+func (a *App) RenderContext() core.RenderContext {
+	return core.RenderContext{
+		Theme:   a.theme,
+		Symbols: a.config.Symbols,
+		Width:   a.config.Width,
+	}
+}
+
 // Render renders any component that implements the Renderable interface.
+// Components that also implement core.ContextRenderable are rendered under
+// the app's RenderContext, so they pick up its symbols and width.
 func (a *App) Render(component core.Renderable) error {
-	output := component.Render(a.theme)
+	output := core.RenderWithContext(component, a.RenderContext())
+	_, err := fmt.Fprint(a.writer, output)
+	return err
+}
+
+// RenderIndented renders a component with prefix in front of every line,
+// e.g. "  | " to nest a boxed result under a log line. Components that
+// implement core.ContextRenderable get the width left after the prefix, the
+// terminal width if none is configured, so they still fit.
+func (a *App) RenderIndented(prefix string, component core.Renderable) error {
+	ctx := a.RenderContext()
+	if ctx.Width <= 0 {
+		ctx.Width, _ = core.GetTerminalSize()
+	}
+	ctx.Width -= core.MeasureText(prefix)
+	if ctx.Width < 1 {
+		ctx.Width = 1
+	}
+
+	output := core.RenderWithContext(component, ctx)
+	trailing := strings.HasSuffix(output, "\n")
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	output = strings.Join(lines, "\n")
+	if trailing {
+		output += "\n"
+	}
+
 	_, err := fmt.Fprint(a.writer, output)
 	return err
 }
@@ -112,17 +246,98 @@ func (a *App) Println(text string, colorFunc ...*style.Color) {
 	a.Print(text+"\n", colorFunc...)
 }
 
-// Clear clears the terminal screen.
+// Verbosity returns the verbosity level.
+func (a *App) Verbosity() int {
+	return a.config.Verbosity
+}
+
+// SetVerbosity changes the verbosity level, e.g. after parsing flags.
+func (a *App) SetVerbosity(level int) {
+	a.config.Verbosity = level
+}
+
+// IsQuiet reports whether the app was asked to be quiet, for components and
+// callers that should skip status output such as spinner success lines.
+func (a *App) IsQuiet() bool {
+	return a.config.Verbosity < VerbosityNormal
+}
+
+// Normal prints a line like Println unless the app is quiet.
+func (a *App) Normal(text string, colorFunc ...*style.Color) {
+	a.printAt(VerbosityNormal, text, colorFunc)
+}
+
+// Verbose prints a line like Println at VerbosityVerbose and above.
+func (a *App) Verbose(text string, colorFunc ...*style.Color) {
+	a.printAt(VerbosityVerbose, text, colorFunc)
+}
+
+// Debug prints a line at VerbosityDebug, in the theme's muted color unless
+// a color is given.
+func (a *App) Debug(text string, colorFunc ...*style.Color) {
+	if len(colorFunc) == 0 {
+		colorFunc = []*style.Color{style.ColorOr(a.theme.Muted, style.Muted)}
+	}
+	a.printAt(VerbosityDebug, text, colorFunc)
+}
+
+// printAt prints a line when the verbosity is at least level.
+func (a *App) printAt(level int, text string, colorFunc []*style.Color) {
+	if a.config.Verbosity >= level {
+		a.Println(text, colorFunc...)
+	}
+}
+
+// Clear clears the terminal screen. Many terminals also lose scrollback, so
+// prefer ClearLines to erase only what the app has drawn.
 func (a *App) Clear() {
 	fmt.Fprint(a.writer, "\033[2J\033[H")
 }
 
+// ClearLines erases the last n lines of output, leaving the cursor at the
+// start of the topmost erased line. Output above them is preserved.
+func (a *App) ClearLines(n int) {
+	fmt.Fprint(a.writer, core.ClearLinesSeq(n))
+}
+
+// Logger returns a logger writing level-tagged lines such as "[WARN]  ..."
+// to the app's writer in the current theme's colors.
+func (a *App) Logger() *ux.Logger {
+	return ux.NewLogger(a.writer, a.theme)
+}
+
 // MoveCursor moves the cursor to the specified position.
 func (a *App) MoveCursor(x, y int) {
 	fmt.Fprintf(a.writer, "\033[%d;%dH", y, x)
 }
 
+// RenderAt renders a component with its top-left corner at column x and row
+// y (both 1-based, as in MoveCursor), moving the cursor to the start of each
+// line so multi-line components such as boxes and tables keep their shape
+// anywhere on screen. The cursor is left at the end of the last line.
+func (a *App) RenderAt(x, y int, component core.Renderable) error {
+	lines := strings.Split(strings.TrimSuffix(core.RenderWithContext(component, a.RenderContext()), "\n"), "\n")
+
+	var out strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&out, "\033[%d;%dH%s", y+i, x, line)
+	}
+	_, err := fmt.Fprint(a.writer, out.String())
+	return err
+}
+
+// HideCursor hides the terminal cursor, e.g. while redrawing an animation.
+// Pair it with a deferred ShowCursor.
+func (a *App) HideCursor() {
+	fmt.Fprint(a.writer, core.HideCursorSeq)
+}
+
+// ShowCursor shows the terminal cursor again.
+func (a *App) ShowCursor() {
+	fmt.Fprint(a.writer, core.ShowCursorSeq)
+}
+
 // Version returns the current version of cmdux.
 func Version() string {
 	return "1.0.0"
-}
\ No newline at end of file
+}
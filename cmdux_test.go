@@ -0,0 +1,205 @@
+package cmdux
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+	"github.com/bagaking/cmdux/ui"
+)
+
+func TestAppSetTheme(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithWriter(&buf), WithColors(true))
+	box := ui.NewBox().Title("Status").Content("All systems go")
+
+	render := func() string {
+		buf.Reset()
+		if err := app.Render(box); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	before := render()
+	app.SetTheme(style.CyberpunkTheme())
+	after := render()
+
+	if before == after {
+		t.Error("expected different output after switching themes")
+	}
+	if !strings.Contains(after, "\x1b[95m") { // Cyberpunk's magenta border
+		t.Errorf("expected the new theme's border color, got %q", after)
+	}
+
+	app.SetColors(false)
+	if plain := render(); strings.Contains(plain, "\x1b[") {
+		t.Errorf("expected no colors after SetColors(false), got %q", plain)
+	}
+	app.SetTheme(style.DarkTheme())
+	if plain := render(); strings.Contains(plain, "\x1b[") {
+		t.Errorf("expected disabled colors to carry over to a new theme, got %q", plain)
+	}
+}
+
+func TestAppNilTheme(t *testing.T) {
+	var buf bytes.Buffer
+	for _, app := range []*App{
+		New(WithWriter(&buf), WithTheme(nil)),
+		New(WithWriter(&buf), WithTheme(nil), WithColors(false)),
+	} {
+		if app.Theme() == nil {
+			t.Fatal("expected a nil theme to select the default theme")
+		}
+		app.SetTheme(nil)
+		if err := app.Render(ui.NewBox().Content("ok")); err != nil || !strings.Contains(buf.String(), "ok") {
+			t.Errorf("Render after SetTheme(nil) = %v, output %q", err, buf.String())
+		}
+		buf.Reset()
+	}
+}
+
+func TestAppRenderAt(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithWriter(&buf), WithColors(false))
+
+	if err := app.RenderAt(10, 5, core.Text("╭──╮\n│hi│\n╰──╯\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "\033[5;10H╭──╮\033[6;10H│hi│\033[7;10H╰──╯"
+	if got := buf.String(); got != expected {
+		t.Errorf("RenderAt() = %q, want %q", got, expected)
+	}
+}
+
+func TestAppSymbols(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithWriter(&buf), WithColors(false), WithSymbols(style.ASCIISymbols()))
+
+	render := func(component core.Renderable) string {
+		buf.Reset()
+		if err := app.Render(component); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got, want := render(ui.NewBox().Content("hi")), "+----+\n| hi |\n+----+"; got != want {
+		t.Errorf("box = %q, want %q", got, want)
+	}
+	if got := render(ui.NewList("one", "two")); got != "* one\n* two" {
+		t.Errorf("list = %q, want ASCII bullets", got)
+	}
+	if got := render(ui.NewToast(ui.ToastSuccess, "Saved", "ok")); !strings.Contains(got, "[ v Saved ]") || strings.ContainsAny(got, "✓╭") {
+		t.Errorf("toast = %q, want ASCII icon and border", got)
+	}
+	table := ui.NewTable().Headers("ID", "Name").AddRow("1", "Ada")
+	if got, want := render(table), "+----+------+\n| ID | Name |\n+----+------+\n| 1  | Ada  |\n+----+------+"; got != want {
+		t.Errorf("table = %q, want %q", got, want)
+	}
+	if got := render(ui.NewKeyValue().Add("Name", "Ada")); got != "Name: Ada" {
+		t.Errorf("key/value = %q", got)
+	}
+	if got := render(ui.NewGauge(50, 0, 100).Width(5).Format("")); got != "--*--" {
+		t.Errorf("gauge = %q, want ASCII track and marker", got)
+	}
+	if got := render(ui.NewList("one").BulletChar("-")); got != "- one" {
+		t.Errorf("list = %q, explicit bullets should be kept", got)
+	}
+
+	app.SetSymbols(style.DefaultSymbols())
+	if got := render(ui.NewBox().Content("hi")); !strings.HasPrefix(got, "╭") {
+		t.Errorf("box = %q, want rounded corners after switching back", got)
+	}
+}
+
+func TestAppRenderIndented(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithWriter(&buf), WithColors(false), func(c *Config) { c.Width = 12 })
+
+	err := app.RenderIndented("  | ", ui.NewBox().Content("a result that needs wrapping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) < 4 {
+		t.Fatalf("expected a wrapped box, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "  | ") {
+			t.Errorf("line %q is missing the prefix", line)
+		}
+		if width := core.MeasureText(line); width > 12 {
+			t.Errorf("line %q is %d cells wide, want at most 12", line, width)
+		}
+	}
+}
+
+func TestAppVerbosity(t *testing.T) {
+	tests := []struct {
+		level int
+		want  string
+	}{
+		{VerbosityQuiet, ""},
+		{VerbosityNormal, "normal\n"},
+		{VerbosityVerbose, "normal\nverbose\n"},
+		{VerbosityDebug, "normal\nverbose\ndebug\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		app := New(WithWriter(&buf), WithColors(false), WithVerbosity(tt.level))
+		app.Normal("normal")
+		app.Verbose("verbose")
+		app.Debug("debug")
+		if got := core.StripANSI(buf.String()); got != tt.want {
+			t.Errorf("verbosity %d: got %q, want %q", tt.level, got, tt.want)
+		}
+		if app.IsQuiet() != (tt.level == VerbosityQuiet) {
+			t.Errorf("verbosity %d: IsQuiet = %v", tt.level, app.IsQuiet())
+		}
+	}
+}
+
+func TestNewSilent(t *testing.T) {
+	var buf bytes.Buffer
+	app := NewSilent(WithWriter(&buf), WithColors(true), WithVerbosity(VerbosityDebug))
+
+	app.Println("hello")
+	app.Debug("details")
+	if err := app.Render(ui.NewBox().Content("hidden")); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("silent app wrote %q", buf.String())
+	}
+	if app.writer != io.Discard {
+		t.Errorf("writer = %T, want io.Discard", app.writer)
+	}
+	if ctx := app.RenderContext(); ctx.Theme.Primary.Sprint("x") != "x" {
+		t.Errorf("silent app should not use colors, got %q", ctx.Theme.Primary.Sprint("x"))
+	}
+	if app.Verbosity() != VerbosityDebug {
+		t.Errorf("options should still apply, got verbosity %d", app.Verbosity())
+	}
+}
+
+func TestAppClearLines(t *testing.T) {
+	var buf bytes.Buffer
+	app := New(WithWriter(&buf))
+
+	app.ClearLines(2)
+	if got := buf.String(); got != core.ClearLinesSeq(2) {
+		t.Errorf("ClearLines(2) wrote %q", got)
+	}
+	buf.Reset()
+	app.ClearLines(0)
+	if buf.Len() != 0 {
+		t.Errorf("ClearLines(0) wrote %q", buf.String())
+	}
+}
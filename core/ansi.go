@@ -0,0 +1,286 @@
+// Package core provides ANSI escape sequence handling.
+package core
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ansiToken is either a run of plain text or a single escape sequence.
+type ansiToken struct {
+	text   string
+	escape bool
+	// final and params describe CSI sequences (ESC [ params final).
+	final  byte
+	params string
+}
+
+// tokenizeANSI splits s into text runs and escape sequences. It understands
+// CSI sequences (ESC [ ... final), OSC sequences (ESC ] ... BEL or ESC \)
+// and two-character escapes.
+func tokenizeANSI(s string) []ansiToken {
+	var tokens []ansiToken
+	textStart := 0
+
+	for i := 0; i < len(s); {
+		if s[i] != 0x1b {
+			i++
+			continue
+		}
+
+		if i > textStart {
+			tokens = append(tokens, ansiToken{text: s[textStart:i]})
+		}
+
+		end, token := scanEscape(s, i)
+		tokens = append(tokens, token)
+		i = end
+		textStart = end
+	}
+
+	if textStart < len(s) {
+		tokens = append(tokens, ansiToken{text: s[textStart:]})
+	}
+	return tokens
+}
+
+// StripANSI removes ANSI escape sequences from text, leaving what a
+// terminal would display: SGR colors, cursor movement and other CSI
+// sequences, OSC sequences such as hyperlinks, and two-character escapes.
+// Together with MeasureText it lets custom components align their output
+// with styled text from cmdux components.
+func StripANSI(text string) string {
+	var out strings.Builder
+	for _, token := range tokenizeANSI(text) {
+		if !token.escape {
+			out.WriteString(token.text)
+		}
+	}
+	return out.String()
+}
+
+// SanitizeText makes untrusted text, such as log lines or user input, safe to
+// embed in a layout. It removes ANSI escape sequences, turns tabs and line
+// breaks into spaces and drops all other control characters, including DEL
+// and the C1 controls, which could otherwise move the cursor, change colors
+// or break alignment.
+func SanitizeText(s string) string {
+	var out strings.Builder
+	for _, token := range tokenizeANSI(s) {
+		if token.escape {
+			continue
+		}
+		for _, r := range strings.ReplaceAll(token.text, "\r\n", "\n") {
+			switch {
+			case r == '\t' || r == '\n' || r == '\r':
+				out.WriteRune(' ')
+			case r < 0x20 || r == 0x7f || r >= 0x80 && r < 0xa0:
+				// Drop other control characters
+			default:
+				out.WriteRune(r)
+			}
+		}
+	}
+	return out.String()
+}
+
+// scanEscape scans the escape sequence starting at s[start] and returns the
+// index just past it.
+func scanEscape(s string, start int) (int, ansiToken) {
+	i := start + 1
+	if i >= len(s) {
+		return i, ansiToken{text: s[start:], escape: true}
+	}
+
+	switch s[i] {
+	case '[':
+		i++
+		paramStart := i
+		for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+			i++
+		}
+		if i >= len(s) {
+			return i, ansiToken{text: s[start:], escape: true}
+		}
+		return i + 1, ansiToken{text: s[start : i+1], escape: true, final: s[i], params: s[paramStart:i]}
+	case ']':
+		for i++; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1, ansiToken{text: s[start : i+1], escape: true}
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, ansiToken{text: s[start : i+2], escape: true}
+			}
+		}
+		return i, ansiToken{text: s[start:], escape: true}
+	default:
+		return i + 1, ansiToken{text: s[start : i+1], escape: true}
+	}
+}
+
+// sgrState tracks the text attributes set by SGR sequences.
+type sgrState struct {
+	fg, bg    string
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+}
+
+func (st sgrState) css() string {
+	var parts []string
+	if st.fg != "" {
+		parts = append(parts, "color:"+st.fg)
+	}
+	if st.bg != "" {
+		parts = append(parts, "background-color:"+st.bg)
+	}
+	if st.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if st.faint {
+		parts = append(parts, "opacity:0.6")
+	}
+	if st.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if st.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+// apply updates the state from the parameters of an SGR (ESC [ ... m) sequence.
+func (st *sgrState) apply(params string) {
+	if params == "" {
+		*st = sgrState{}
+		return
+	}
+
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			*st = sgrState{}
+		case code == 1:
+			st.bold = true
+		case code == 2:
+			st.faint = true
+		case code == 3:
+			st.italic = true
+		case code == 4:
+			st.underline = true
+		case code == 22:
+			st.bold, st.faint = false, false
+		case code == 23:
+			st.italic = false
+		case code == 24:
+			st.underline = false
+		case code >= 30 && code <= 37:
+			st.fg = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			st.fg = ansiPalette[code-90+8]
+		case code == 39:
+			st.fg = ""
+		case code >= 40 && code <= 47:
+			st.bg = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			st.bg = ansiPalette[code-100+8]
+		case code == 49:
+			st.bg = ""
+		case code == 38 || code == 48:
+			color, consumed := extendedColor(codes[i+1:])
+			i += consumed
+			if code == 38 {
+				st.fg = color
+			} else {
+				st.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor parses the arguments of a 38/48 SGR code ("5;n" or "2;r;g;b")
+// and returns the CSS color and the number of codes consumed.
+func extendedColor(codes []string) (string, int) {
+	if len(codes) == 0 {
+		return "", 0
+	}
+
+	switch codes[0] {
+	case "5":
+		if len(codes) < 2 {
+			return "", len(codes)
+		}
+		n, err := strconv.Atoi(codes[1])
+		if err != nil || n < 0 || n > 255 {
+			return "", 2
+		}
+		return xterm256(n), 2
+	case "2":
+		if len(codes) < 4 {
+			return "", len(codes)
+		}
+		rgb := make([]int, 3)
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(codes[i+1])
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0]&0xff, rgb[1]&0xff, rgb[2]&0xff), 4
+	}
+	return "", 1
+}
+
+// ansiPalette holds the CSS colors of the 16 basic ANSI colors (xterm defaults).
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// xterm256 returns the CSS color for an index of the xterm 256-color palette.
+func xterm256(n int) string {
+	switch {
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[(n/6)%6], levels[n%6])
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// ANSIToHTML converts ANSI-colored text, such as the output of a component's
+// Render method, into HTML with inline CSS. Text is HTML-escaped, styled runs
+// are wrapped in <span style="..."> elements and other escape sequences are
+// dropped. Newlines are preserved, so the result is best placed in a <pre>.
+func ANSIToHTML(s string) string {
+	var result strings.Builder
+	var state sgrState
+
+	for _, token := range tokenizeANSI(s) {
+		if token.escape {
+			if token.final == 'm' {
+				state.apply(token.params)
+			}
+			continue
+		}
+
+		text := html.EscapeString(token.text)
+		if css := state.css(); css != "" {
+			result.WriteString(`<span style="` + css + `">` + text + `</span>`)
+		} else {
+			result.WriteString(text)
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,69 @@
+package core
+
+import "testing"
+
+func TestANSIToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain text is escaped",
+			input:    "a < b & c",
+			expected: "a &lt; b &amp; c",
+		},
+		{
+			name:     "Basic foreground with bold",
+			input:    "\x1b[96;1mHi\x1b[0m there",
+			expected: `<span style="color:#00ffff;font-weight:bold">Hi</span> there`,
+		},
+		{
+			name:     "Specific resets",
+			input:    "\x1b[31;4mred\x1b[24m plain\x1b[39m",
+			expected: `<span style="color:#cd0000;text-decoration:underline">red</span><span style="color:#cd0000"> plain</span>`,
+		},
+		{
+			name:     "256 and truecolor",
+			input:    "\x1b[38;5;208mA\x1b[48;2;1;2;3mB\x1b[0m",
+			expected: `<span style="color:#ff8700">A</span><span style="color:#ff8700;background-color:#010203">B</span>`,
+		},
+		{
+			name:     "Non-SGR sequences are dropped",
+			input:    "\x1b[2K\rok\x1b]0;title\a",
+			expected: "\rok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ANSIToHTML(tt.input); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"colors", "\x1b[1;31mred\x1b[0m text", "red text"},
+		{"hyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"OSC ended by BEL", "\x1b]0;title\aafter", "after"},
+		{"non-letter CSI final", "a\x1b[2~b", "ab"},
+		{"two-character escape", "\x1b7saved\x1b8", "saved"},
+		{"wide text", "\x1b[32m中文\x1b[0m", "中文"},
+	}
+
+	for _, tt := range tests {
+		if got := StripANSI(tt.input); got != tt.want {
+			t.Errorf("%s: StripANSI(%q) = %q, want %q", tt.name, tt.input, got, tt.want)
+		}
+	}
+	if got := MeasureText("\x1b]8;;https://example.com\x1b\\中文\x1b]8;;\x1b\\"); got != 4 {
+		t.Errorf("MeasureText of a wide hyperlink = %d, want 4", got)
+	}
+}
@@ -1,11 +1,17 @@
 // Package core provides the fundamental interfaces and types for cmdux.
 package core
 
-import "github.com/bagaking/cmdux/style"
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/style"
+)
 
 // Renderable represents any component that can be rendered to the terminal.
 type Renderable interface {
 	// Render returns the string representation of the component using the given theme.
+	// Implementations must not modify the component, so one configured component
+	// can be rendered repeatedly, and concurrently, under different themes.
 	Render(theme *style.Theme) string
 }
 
@@ -26,6 +32,12 @@ func NewComponent() *Component {
 	}
 }
 
+// Clone returns a copy of the component. The style is shared.
+func (c *Component) Clone() *Component {
+	clone := *c
+	return &clone
+}
+
 // Width sets the component width.
 func (c *Component) Width(w int) *Component {
 	c.width = w
@@ -74,4 +86,27 @@ func (c *Component) SetStyle(s *style.Style) *Component {
 // GetStyle returns the component style.
 func (c *Component) GetStyle() *style.Style {
 	return c.style
-}
\ No newline at end of file
+}
+
+// Measure renders r with theme and returns the width of its widest line and
+// its number of lines, ignoring ANSI codes. An empty render measures 0x0.
+func Measure(r Renderable, theme *style.Theme) (width, height int) {
+	output := r.Render(theme)
+	if output == "" {
+		return 0, 0
+	}
+
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		if lineWidth := MeasureText(line); lineWidth > width {
+			width = lineWidth
+		}
+	}
+	return width, len(lines)
+}
+
+// RenderPlain renders r and removes every ANSI escape sequence, giving clean
+// aligned text for files and copy-paste regardless of the color setting.
+func RenderPlain(r Renderable) string {
+	return StripANSI(r.Render(style.DefaultTheme()))
+}
@@ -0,0 +1,67 @@
+// Package core provides the context-aware render path.
+package core
+
+import "github.com/bagaking/cmdux/style"
+
+// RenderContext carries everything a component may need to render beyond
+// its own configuration: the color theme, the glyphs to draw with and the
+// available width. It lets one application-level setting, such as switching
+// to style.ASCIISymbols, reach every component that opts in.
+type RenderContext struct {
+	// Theme is the color theme.
+	Theme *style.Theme
+	// Symbols provides the glyphs used for borders, bullets and markers.
+	// Components only take glyphs from it that they were not given
+	// explicitly.
+	Symbols style.SymbolSet
+	// Width is the available width in cells, or 0 for no limit. Components
+	// that size themselves to their content stay within it unless they were
+	// given a width of their own.
+	Width int
+}
+
+// NewRenderContext returns a context with the default symbols and no width
+// limit, under which context-aware components render as Render(theme) does.
+func NewRenderContext(theme *style.Theme) RenderContext {
+	return RenderContext{Theme: theme, Symbols: style.DefaultSymbols()}
+}
+
+// ContextRenderable is implemented by components that can render from a
+// RenderContext. It is an opt-in extension of Renderable: RenderWithContext
+// uses it when available and falls back to Render(ctx.Theme) otherwise.
+type ContextRenderable interface {
+	Renderable
+	// RenderContext renders the component under ctx. Like Render it must not
+	// modify the component.
+	RenderContext(ctx RenderContext) string
+}
+
+// RenderWithContext renders a component under ctx, through RenderContext if
+// the component implements ContextRenderable and Render(ctx.Theme) if not.
+func RenderWithContext(component Renderable, ctx RenderContext) string {
+	if contextual, ok := component.(ContextRenderable); ok {
+		return contextual.RenderContext(ctx)
+	}
+	return component.Render(ctx.Theme)
+}
+
+// SymbolBoxChars returns the box drawing characters of a symbol set, e.g.
+// style.ASCIISymbols, taking the first rune of each glyph.
+func SymbolBoxChars(symbols style.SymbolSet) BoxChars {
+	first := func(glyph string) rune {
+		for _, r := range glyph {
+			return r
+		}
+		return ' '
+	}
+	return BoxChars{
+		TopLeft:     first(symbols.BoxTopLeft),
+		TopRight:    first(symbols.BoxTopRight),
+		BottomLeft:  first(symbols.BoxBottomLeft),
+		BottomRight: first(symbols.BoxBottomRight),
+		Horizontal:  first(symbols.BoxHorizontal),
+		Vertical:    first(symbols.BoxVertical),
+		LeftTee:     first(symbols.BoxTee),
+		RightTee:    first(symbols.BoxTeeRight),
+	}
+}
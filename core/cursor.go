@@ -0,0 +1,66 @@
+// Package core provides cursor visibility control.
+package core
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// ANSI sequences that hide and show the terminal cursor.
+const (
+	HideCursorSeq = "\033[?25l"
+	ShowCursorSeq = "\033[?25h"
+)
+
+// CursorGuard hides the cursor while an animation runs and makes sure it is
+// shown again, either when Show is called or when the process receives SIGINT
+// or SIGTERM while the cursor is hidden. Writes to a file that is not a
+// terminal are skipped, so redirected output stays free of escape codes.
+type CursorGuard struct {
+	writer       io.Writer
+	mu           sync.Mutex
+	hidden       bool
+	stopWatching func()
+}
+
+// NewCursorGuard creates a guard writing to w, usually os.Stdout.
+func NewCursorGuard(w io.Writer) *CursorGuard {
+	return &CursorGuard{writer: w}
+}
+
+// Hide hides the cursor. Hiding an already hidden cursor is a no-op.
+func (g *CursorGuard) Hide() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.hidden || !g.enabled() {
+		return
+	}
+	io.WriteString(g.writer, HideCursorSeq)
+	g.hidden = true
+	g.stopWatching = onInterrupt(g.Show)
+}
+
+// Show shows the cursor again if Hide hid it. It is safe to call at any time,
+// so it can always be deferred.
+func (g *CursorGuard) Show() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.hidden {
+		return
+	}
+	g.stopWatching()
+	io.WriteString(g.writer, ShowCursorSeq)
+	g.hidden = false
+}
+
+func (g *CursorGuard) enabled() bool {
+	if file, ok := g.writer.(*os.File); ok {
+		return term.IsTerminal(int(file.Fd()))
+	}
+	return g.writer != nil
+}
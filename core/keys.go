@@ -0,0 +1,167 @@
+// Package core provides keyboard input decoding.
+package core
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// KeyType identifies the kind of a decoded key press.
+type KeyType int
+
+const (
+	// KeyRune is a printable character; see Key.Rune.
+	KeyRune KeyType = iota
+	// KeyUp is the up arrow.
+	KeyUp
+	// KeyDown is the down arrow.
+	KeyDown
+	// KeyLeft is the left arrow.
+	KeyLeft
+	// KeyRight is the right arrow.
+	KeyRight
+	// KeyHome is the Home key.
+	KeyHome
+	// KeyEnd is the End key.
+	KeyEnd
+	// KeyPageUp is the Page Up key.
+	KeyPageUp
+	// KeyPageDown is the Page Down key.
+	KeyPageDown
+	// KeyDelete is the forward Delete key.
+	KeyDelete
+	// KeyEnter is Enter or Return.
+	KeyEnter
+	// KeyEscape is a lone Escape press.
+	KeyEscape
+	// KeyBackspace is Backspace.
+	KeyBackspace
+	// KeyTab is Tab.
+	KeyTab
+	// KeyCtrlC is Ctrl-C. In raw mode it does not raise SIGINT.
+	KeyCtrlC
+	// KeyCtrlD is Ctrl-D (end of input).
+	KeyCtrlD
+	// KeyUnknown is an unrecognized control character or escape sequence.
+	KeyUnknown
+)
+
+// Key is a single decoded key press.
+type Key struct {
+	Type KeyType
+	// Rune holds the character for KeyRune events.
+	Rune rune
+}
+
+// KeyReader decodes bytes from a raw-mode terminal into Key events, including
+// multi-byte escape sequences such as the arrow keys.
+type KeyReader struct {
+	reader *bufio.Reader
+}
+
+// NewKeyReader creates a key reader. If r is already a *bufio.Reader it is
+// used directly, so input buffered by the caller is not lost.
+func NewKeyReader(r io.Reader) *KeyReader {
+	return &KeyReader{reader: bufio.NewReader(r)}
+}
+
+// ReadKey blocks until a key is pressed and returns it.
+func (kr *KeyReader) ReadKey() (Key, error) {
+	r, _, err := kr.reader.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return Key{Type: KeyEnter}, nil
+	case '\t':
+		return Key{Type: KeyTab}, nil
+	case 127, '\b':
+		return Key{Type: KeyBackspace}, nil
+	case 3:
+		return Key{Type: KeyCtrlC}, nil
+	case 4:
+		return Key{Type: KeyCtrlD}, nil
+	case 0x1b:
+		return kr.readEscape()
+	}
+
+	if r < ' ' || r == utf8.RuneError {
+		return Key{Type: KeyUnknown, Rune: r}, nil
+	}
+	return Key{Type: KeyRune, Rune: r}, nil
+}
+
+// readEscape decodes the rest of an escape sequence. Terminals send a whole
+// sequence in one write, so an ESC with nothing buffered behind it is treated
+// as a lone Escape press.
+func (kr *KeyReader) readEscape() (Key, error) {
+	if kr.reader.Buffered() == 0 {
+		return Key{Type: KeyEscape}, nil
+	}
+
+	introducer, err := kr.reader.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch introducer {
+	case '[':
+		return kr.readCSI()
+	case 'O': // SS3, sent by some terminals in application cursor mode
+		final, err := kr.reader.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		return csiKey(final, ""), nil
+	default:
+		return Key{Type: KeyUnknown}, nil
+	}
+}
+
+func (kr *KeyReader) readCSI() (Key, error) {
+	var params []byte
+	for {
+		b, err := kr.reader.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return csiKey(b, string(params)), nil
+		}
+		params = append(params, b)
+	}
+}
+
+func csiKey(final byte, params string) Key {
+	switch final {
+	case 'A':
+		return Key{Type: KeyUp}
+	case 'B':
+		return Key{Type: KeyDown}
+	case 'C':
+		return Key{Type: KeyRight}
+	case 'D':
+		return Key{Type: KeyLeft}
+	case 'H':
+		return Key{Type: KeyHome}
+	case 'F':
+		return Key{Type: KeyEnd}
+	case '~':
+		switch params {
+		case "1", "7":
+			return Key{Type: KeyHome}
+		case "4", "8":
+			return Key{Type: KeyEnd}
+		case "3":
+			return Key{Type: KeyDelete}
+		case "5":
+			return Key{Type: KeyPageUp}
+		case "6":
+			return Key{Type: KeyPageDown}
+		}
+	}
+	return Key{Type: KeyUnknown}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestKeyReaderDecodesSequences(t *testing.T) {
+	input := "a\x1b[A\x1b[B\x1bOC\x1b[D\r\x7f\t\x03 é\x1b[5~\x1b[6~\x1b[3~\x1b"
+	expected := []Key{
+		{Type: KeyRune, Rune: 'a'},
+		{Type: KeyUp},
+		{Type: KeyDown},
+		{Type: KeyRight},
+		{Type: KeyLeft},
+		{Type: KeyEnter},
+		{Type: KeyBackspace},
+		{Type: KeyTab},
+		{Type: KeyCtrlC},
+		{Type: KeyRune, Rune: ' '},
+		{Type: KeyRune, Rune: 'é'},
+		{Type: KeyPageUp},
+		{Type: KeyPageDown},
+		{Type: KeyDelete},
+		{Type: KeyEscape},
+	}
+
+	reader := NewKeyReader(strings.NewReader(input))
+	for i, want := range expected {
+		got, err := reader.ReadKey()
+		if err != nil {
+			t.Fatalf("Key %d: unexpected error: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("Key %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if _, err := reader.ReadKey(); err != io.EOF {
+		t.Errorf("Expected io.EOF after input, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Package core provides in-place redrawing for interactive components.
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrCancelled is returned when the user cancels an interactive component
+// with Ctrl-C or Esc.
+var ErrCancelled = errors.New("input cancelled")
+
+// ErrAborted is returned when the input ends (Ctrl-D or a closed stdin)
+// before an answer was given.
+var ErrAborted = errors.New("input aborted")
+
+// ClearLinesSeq returns the ANSI sequence that erases the n lines above the
+// cursor and leaves it at the start of the topmost one. Unlike clearing the
+// screen it keeps earlier output and the scrollback intact.
+func ClearLinesSeq(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("\033[1A\033[2K", n) + "\r"
+}
+
+// LiveRegion redraws a block of lines in place on a raw-mode terminal, e.g.
+// a list whose highlighted row moves with the arrow keys.
+type LiveRegion struct {
+	// Writer receives the lines. Defaults to os.Stdout.
+	Writer io.Writer
+
+	count int
+}
+
+// Draw replaces the previously drawn lines with the given ones. Lines end in
+// "\r\n" because raw mode disables the terminal's newline translation.
+func (l *LiveRegion) Draw(lines []string) {
+	var out strings.Builder
+	if l.count > 0 {
+		out.WriteString(fmt.Sprintf("\033[%dA", l.count))
+	}
+	for _, line := range lines {
+		out.WriteString("\r\033[2K" + line + "\r\n")
+	}
+	w := l.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	fmt.Fprint(w, out.String())
+	l.count = len(lines)
+}
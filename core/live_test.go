@@ -0,0 +1,39 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestClearLinesSeq(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{-1, ""},
+		{0, ""},
+		{1, "\033[1A\033[2K\r"},
+		{3, "\033[1A\033[2K\033[1A\033[2K\033[1A\033[2K\r"},
+	}
+	for _, tt := range tests {
+		if got := ClearLinesSeq(tt.n); got != tt.expected {
+			t.Errorf("ClearLinesSeq(%d) = %q, want %q", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestLiveRegionDraw(t *testing.T) {
+	var buf bytes.Buffer
+	screen := &LiveRegion{Writer: &buf}
+
+	screen.Draw([]string{"one", "two"})
+	if got, want := buf.String(), "\r\033[2Kone\r\n\r\033[2Ktwo\r\n"; got != want {
+		t.Errorf("first draw = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	screen.Draw([]string{"three"})
+	if got, want := buf.String(), "\033[2A\r\033[2Kthree\r\n"; got != want {
+		t.Errorf("redraw = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+// Package core provides option matching for selections.
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Matcher reports whether input, e.g. typed by the user or read from a
+// config file, selects option.
+type Matcher func(option, input string) bool
+
+// ExactMatch matches options by exact string equality.
+func ExactMatch(option, input string) bool {
+	return option == input
+}
+
+// FoldMatch matches options ignoring case, surrounding whitespace and runs of
+// inner whitespace, so "  dark  MODE" selects "Dark mode".
+func FoldMatch(option, input string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(option), " "), strings.Join(strings.Fields(input), " "))
+}
+
+// FindOption returns the index of the first option that input selects
+// according to match, or -1. A nil match uses ExactMatch.
+func FindOption(options []string, input string, match Matcher) int {
+	if match == nil {
+		match = ExactMatch
+	}
+	for i, option := range options {
+		if match(option, input) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseRange parses a number such as "3", or an inclusive range of numbers
+// such as "1-3" or "2 - 4", as typed to pick entries from a numbered list.
+// A single number is returned as both low and high. The bounds are not
+// checked, so low may exceed high.
+func ParseRange(input string) (low, high int, ok bool) {
+	from, to, isRange := strings.Cut(input, "-")
+	if !isRange {
+		to = from
+	}
+	low, lowErr := strconv.Atoi(strings.TrimSpace(from))
+	high, highErr := strconv.Atoi(strings.TrimSpace(to))
+	if lowErr != nil || highErr != nil {
+		return 0, 0, false
+	}
+	return low, high, true
+}
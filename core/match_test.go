@@ -0,0 +1,47 @@
+package core
+
+import "testing"
+
+func TestFindOption(t *testing.T) {
+	options := []string{"Dark mode", "Light mode", "dark"}
+	tests := []struct {
+		input    string
+		match    Matcher
+		expected int
+	}{
+		{"Dark mode", nil, 0},
+		{"dark mode", nil, -1},
+		{"  dark   MODE ", FoldMatch, 0},
+		{"DARK", FoldMatch, 2},
+		{"light", FoldMatch, -1},
+	}
+
+	for _, tt := range tests {
+		if got := FindOption(options, tt.input, tt.match); got != tt.expected {
+			t.Errorf("FindOption(%q) = %d, want %d", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		input     string
+		low, high int
+		ok        bool
+	}{
+		{"3", 3, 3, true},
+		{"1-3", 1, 3, true},
+		{" 2 - 4 ", 2, 4, true},
+		{"4-2", 4, 2, true},
+		{"x", 0, 0, false},
+		{"1-", 0, 0, false},
+		{"a-b", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		low, high, ok := ParseRange(tt.input)
+		if low != tt.low || high != tt.high || ok != tt.ok {
+			t.Errorf("ParseRange(%q) = %d, %d, %v; want %d, %d, %v", tt.input, low, high, ok, tt.low, tt.high, tt.ok)
+		}
+	}
+}
@@ -3,9 +3,12 @@ package core
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
 )
 
 // Renderer provides utilities for rendering text with proper alignment and sizing.
@@ -28,9 +31,12 @@ func (r *Renderer) PadText(text string, width int, align Alignment) string {
 		return text
 	}
 	
-	textWidth := runewidth.StringWidth(text)
-	if textWidth >= width {
-		return runewidth.Truncate(text, width, "…")
+	textWidth := MeasureText(text)
+	if textWidth == width {
+		return text
+	}
+	if textWidth > width {
+		return TruncateCells(text, width)
 	}
 	
 	padding := width - textWidth
@@ -43,6 +49,8 @@ func (r *Renderer) PadText(text string, width int, align Alignment) string {
 		leftPad := padding / 2
 		rightPad := padding - leftPad
 		return strings.Repeat(" ", leftPad) + text + strings.Repeat(" ", rightPad)
+	case AlignJustify:
+		return justifyLine(text, width)
 	default:
 		return text + strings.Repeat(" ", padding)
 	}
@@ -69,7 +77,7 @@ func (r *Renderer) WrapText(text string, width int) []string {
 		}
 		testLine += word
 		
-		if runewidth.StringWidth(testLine) <= width {
+		if StringWidth(testLine) <= width {
 			currentLine = testLine
 		} else {
 			if currentLine != "" {
@@ -77,7 +85,7 @@ func (r *Renderer) WrapText(text string, width int) []string {
 				currentLine = word
 			} else {
 				// Word is longer than width, truncate it
-				lines = append(lines, runewidth.Truncate(word, width, "…"))
+				lines = append(lines, TruncateCells(word, width))
 				currentLine = ""
 			}
 		}
@@ -90,12 +98,70 @@ func (r *Renderer) WrapText(text string, width int) []string {
 	return lines
 }
 
+// justifyLine distributes the spare width of a line between its words, giving
+// the leftmost gaps one extra space when it does not divide evenly. Lines with
+// a single word are left-aligned.
+func justifyLine(line string, width int) string {
+	words := strings.Fields(line)
+	if len(words) < 2 {
+		return line + strings.Repeat(" ", width-StringWidth(line))
+	}
+
+	spaces := width
+	for _, word := range words {
+		spaces -= StringWidth(word)
+	}
+	gaps := len(words) - 1
+
+	var justified strings.Builder
+	for i, word := range words {
+		justified.WriteString(word)
+		if i < gaps {
+			gap := spaces / gaps
+			if i < spaces%gaps {
+				gap++
+			}
+			justified.WriteString(strings.Repeat(" ", gap))
+		}
+	}
+	return justified.String()
+}
+
 // TruncateText truncates text to fit within the specified width.
 func (r *Renderer) TruncateText(text string, width int) string {
 	if width <= 0 {
 		return ""
 	}
-	return runewidth.Truncate(text, width, "…")
+	return TruncateCells(text, width)
+}
+
+// TruncateWords truncates text to fit within width at a word boundary,
+// e.g. "Very Long Title…" rather than "Very Long Tit…". If not even the first
+// word fits, it falls back to character truncation like TruncateText.
+func (r *Renderer) TruncateWords(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if StringWidth(text) <= width {
+		return text
+	}
+
+	// Keep the longest run of whole words that leaves room for the ellipsis
+	budget := width - StringWidth("…")
+	cut := -1
+	for i, ch := range text {
+		if ch != ' ' || i == 0 || text[i-1] == ' ' {
+			continue
+		}
+		if StringWidth(text[:i]) > budget {
+			break
+		}
+		cut = i
+	}
+	if cut < 0 {
+		return TruncateCells(text, width)
+	}
+	return text[:cut] + "…"
 }
 
 // CenterText centers text within the specified width.
@@ -140,9 +206,28 @@ const (
 	AlignCenter
 	// AlignRight aligns text to the right.
 	AlignRight
+	// AlignJustify stretches the spaces between words so the line fills the
+	// width. The last line of a wrapped paragraph stays left-aligned.
+	AlignJustify
 )
 
-// Box draws a box around text with the specified characters.
+// Mirrored returns the alignment to use for right-to-left text such as Arabic
+// or Hebrew: left and right swap, so text starts at the right edge. Centered
+// and justified text is unchanged; the terminal still orders the characters.
+func (a Alignment) Mirrored() Alignment {
+	switch a {
+	case AlignLeft:
+		return AlignRight
+	case AlignRight:
+		return AlignLeft
+	default:
+		return a
+	}
+}
+
+// Box draws a box around text with the specified characters. Lines are
+// measured by display width, ignoring ANSI codes, and lines wider than the
+// box are truncated with "…".
 func (r *Renderer) Box(content string, width, height int, chars BoxChars) string {
 	if width < 3 || height < 3 {
 		return content
@@ -153,14 +238,15 @@ func (r *Renderer) Box(content string, width, height int, chars BoxChars) string
 	
 	lines := strings.Split(content, "\n")
 	
-	// Prepare content lines
+	// Prepare content lines, measuring display width so styled and wide
+	// text still lines up with the right border
 	var contentLines []string
 	for i := 0; i < contentHeight; i++ {
+		line := ""
 		if i < len(lines) {
-			contentLines = append(contentLines, r.PadText(lines[i], contentWidth, AlignLeft))
-		} else {
-			contentLines = append(contentLines, strings.Repeat(" ", contentWidth))
+			line = TruncateCells(lines[i], contentWidth)
 		}
+		contentLines = append(contentLines, line+strings.Repeat(" ", contentWidth-MeasureText(line)))
 	}
 	
 	// Build the box
@@ -191,6 +277,8 @@ type BoxChars struct {
 	BottomRight rune
 	Horizontal  rune
 	Vertical    rune
+	LeftTee     rune // Joins a divider to the left border
+	RightTee    rune // Joins a divider to the right border
 }
 
 // DefaultBoxChars returns the default box drawing characters.
@@ -202,6 +290,50 @@ func DefaultBoxChars() BoxChars {
 		BottomRight: '╯',
 		Horizontal:  '─',
 		Vertical:    '│',
+		LeftTee:     '├',
+		RightTee:    '┤',
+	}
+}
+
+// SquareBoxChars returns box drawing characters with square corners.
+func SquareBoxChars() BoxChars {
+	return BoxChars{
+		TopLeft:     '┌',
+		TopRight:    '┐',
+		BottomLeft:  '└',
+		BottomRight: '┘',
+		Horizontal:  '─',
+		Vertical:    '│',
+		LeftTee:     '├',
+		RightTee:    '┤',
+	}
+}
+
+// DoubleBoxChars returns double-line box drawing characters.
+func DoubleBoxChars() BoxChars {
+	return BoxChars{
+		TopLeft:     '╔',
+		TopRight:    '╗',
+		BottomLeft:  '╚',
+		BottomRight: '╝',
+		Horizontal:  '═',
+		Vertical:    '║',
+		LeftTee:     '╠',
+		RightTee:    '╣',
+	}
+}
+
+// HeavyBoxChars returns heavy (thick) box drawing characters.
+func HeavyBoxChars() BoxChars {
+	return BoxChars{
+		TopLeft:     '┏',
+		TopRight:    '┓',
+		BottomLeft:  '┗',
+		BottomRight: '┛',
+		Horizontal:  '━',
+		Vertical:    '┃',
+		LeftTee:     '┣',
+		RightTee:    '┫',
 	}
 }
 
@@ -214,41 +346,27 @@ func ClassicBoxChars() BoxChars {
 		BottomRight: '+',
 		Horizontal:  '-',
 		Vertical:    '|',
+		LeftTee:     '+',
+		RightTee:    '+',
 	}
 }
 
-// GetTerminalSize attempts to get the terminal size. Returns default values if unable to detect.
+// GetTerminalSize returns the size of the terminal attached to stdout. When
+// stdout is not a terminal it falls back to the COLUMNS and LINES environment
+// variables, then to 80x24.
 func GetTerminalSize() (width, height int) {
-	// This is a simplified implementation
-	// In a real implementation, you would use terminal size detection
-	return 80, 24
-}
-
-// StripANSI removes ANSI escape codes from a string for width calculation.
-func StripANSI(str string) string {
-	var result strings.Builder
-	inEscape := false
-	
-	for _, r := range str {
-		if r == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
-				inEscape = false
-			}
-			continue
-		}
-		result.WriteRune(r)
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 && h > 0 {
+		return w, h
 	}
-	
-	return result.String()
-}
 
-// MeasureText measures the display width of text, handling ANSI codes and unicode.
-func MeasureText(text string) int {
-	return runewidth.StringWidth(StripANSI(text))
+	width, height = 80, 24
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		height = lines
+	}
+	return width, height
 }
 
 // FormatTable formats a table with proper column alignment and spacing.
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateWords(t *testing.T) {
+	r := NewRenderer(80, 24)
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"Very Long Title That Should Be Truncated", 16, "Very Long Title…"},
+		{"Very Long Title That Should Be Truncated", 15, "Very Long…"},
+		{"Short", 10, "Short"},
+		{"Supercalifragilistic words", 10, "Supercali…"},
+		{"部署 状态 很长", 6, "部署…"},
+		{"anything", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := r.TruncateWords(tt.text, tt.width); got != tt.expected {
+			t.Errorf("TruncateWords(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestRendererBoxMeasuresDisplayWidth(t *testing.T) {
+	content := "\033[31mred\033[0m\n中文字\n\033[1mbold text that is far too long\033[0m"
+	box := NewRenderer(0, 0).Box(content, 12, 5, DefaultBoxChars())
+
+	lines := strings.Split(box, "\n")
+	for _, line := range lines {
+		if width := MeasureText(line); width != 12 {
+			t.Errorf("line %q is %d cells wide, want 12", line, width)
+		}
+	}
+	if !strings.Contains(lines[1], "\033[31mred\033[0m") {
+		t.Errorf("expected styling to be kept, got %q", lines[1])
+	}
+	if got := StripANSI(lines[3]); got != "│bold text…│" {
+		t.Errorf("expected the long line truncated, got %q", got)
+	}
+	if !strings.HasSuffix(lines[3], "\033[0m│") {
+		t.Errorf("expected the style closed before the border, got %q", lines[3])
+	}
+}
@@ -0,0 +1,132 @@
+// Package core provides terminal state management.
+package core
+
+import (
+	"bufio"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// TerminalGuard switches a terminal into raw mode and makes sure its previous
+// state is restored, either when Release is called or when the process receives
+// SIGINT or SIGTERM while the guard is held.
+//
+// Interactive components should acquire the guard on entry and release it via
+// defer so the terminal is also restored on panic:
+//
+//	guard := core.NewTerminalGuard(os.Stdin)
+//	if err := guard.Acquire(); err != nil {
+//		return err
+//	}
+//	defer guard.Release()
+type TerminalGuard struct {
+	file         *os.File
+	mu           sync.Mutex
+	state        *term.State
+	stopWatching func()
+}
+
+// NewTerminalGuard creates a guard for the given terminal, usually os.Stdin.
+func NewTerminalGuard(file *os.File) *TerminalGuard {
+	return &TerminalGuard{file: file}
+}
+
+// IsTerminal reports whether the guarded file is connected to a terminal.
+func (g *TerminalGuard) IsTerminal() bool {
+	return g.file != nil && term.IsTerminal(int(g.file.Fd()))
+}
+
+// Acquire saves the terminal state, enters raw mode and installs a signal
+// handler that restores the saved state before the process exits.
+// Acquiring a guard that is already held is a no-op.
+func (g *TerminalGuard) Acquire() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state != nil {
+		return nil
+	}
+
+	state, err := term.MakeRaw(int(g.file.Fd()))
+	if err != nil {
+		return err
+	}
+
+	g.state = state
+	g.stopWatching = onInterrupt(func() { g.Release() })
+
+	return nil
+}
+
+// Release restores the saved terminal state and removes the signal handler.
+// It is safe to call on a guard that is not held, so it can always be deferred.
+func (g *TerminalGuard) Release() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state == nil {
+		return nil
+	}
+
+	g.stopWatching()
+
+	err := term.Restore(int(g.file.Fd()), g.state)
+	g.state = nil
+	return err
+}
+
+// onInterrupt runs cleanup and exits the process if SIGINT or SIGTERM arrives
+// before the returned stop function is called.
+func onInterrupt(cleanup func()) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			cleanup()
+			os.Exit(signalExitCode(sig))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// signalExitCode follows the shell convention of 128 + signal number.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
+// stdin is the reader shared by everything that reads os.Stdin; see Stdin.
+var stdin struct {
+	mu     sync.Mutex
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// Stdin returns the buffered reader that prompts, pickers and pagers read
+// os.Stdin through. Sharing it keeps input one component buffered, such as
+// the next lines of piped answers, for the components after it. A new reader
+// is made when os.Stdin is replaced.
+func Stdin() *bufio.Reader {
+	stdin.mu.Lock()
+	defer stdin.mu.Unlock()
+
+	if stdin.reader == nil || stdin.file != os.Stdin {
+		stdin.file = os.Stdin
+		stdin.reader = bufio.NewReader(os.Stdin)
+	}
+	return stdin.reader
+}
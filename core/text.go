@@ -0,0 +1,29 @@
+// Package core provides plain text renderables.
+package core
+
+import "github.com/bagaking/cmdux/style"
+
+// textRenderable renders a fixed string, optionally in a color.
+type textRenderable struct {
+	text  string
+	color *style.Color
+}
+
+// Text returns a Renderable that renders s unchanged, so plain lines can be
+// mixed with components wherever a Renderable is expected.
+func Text(s string) Renderable {
+	return textRenderable{text: s}
+}
+
+// StyledText returns a Renderable that renders s in the given color.
+func StyledText(s string, color *style.Color) Renderable {
+	return textRenderable{text: s, color: color}
+}
+
+// Render returns the text, colored if a color was given.
+func (t textRenderable) Render(theme *style.Theme) string {
+	if t.color == nil {
+		return t.text
+	}
+	return t.color.Sprint(t.text)
+}
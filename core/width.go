@@ -0,0 +1,148 @@
+// Package core provides grapheme-aware display width measurement.
+package core
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/internal/cellwidth"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// StringWidth returns the number of terminal cells plain text occupies. It
+// counts user-perceived characters (grapheme clusters) rather than runes, so
+// combining accents and zero-width characters add nothing, an emoji ZWJ
+// sequence such as "👨‍👩‍👧‍👦" counts as a single emoji, and flags and
+// characters with the emoji variation selector are two cells wide. Use
+// MeasureText for text that may contain ANSI escape sequences.
+func StringWidth(text string) int {
+	return cellwidth.String(text)
+}
+
+// MeasureText returns the number of terminal cells text occupies, ignoring
+// ANSI escape sequences (see StripANSI) and counting wide, combining and
+// zero-width characters as StringWidth does. Use it to pad or align styled
+// text.
+func MeasureText(text string) int {
+	return StringWidth(StripANSI(text))
+}
+
+// SetAmbiguousWidth sets whether characters of ambiguous East Asian width,
+// such as "…", "①" and "→", take one cell (narrow) or two. It adjusts
+// runewidth.DefaultCondition, so it also applies to truncation. By default
+// they are wide only in CJK locales (or when RUNEWIDTH_EASTASIAN=1).
+//
+// This is a terminal-specific tuning knob for users whose terminal or font
+// disagrees with the locale, which shows up as misaligned box and table
+// borders. Call it once at startup, before anything is rendered; it is not
+// safe to call concurrently with rendering.
+func SetAmbiguousWidth(narrow bool) {
+	runewidth.DefaultCondition.EastAsianWidth = !narrow
+}
+
+// SetEmojiWidth sets how many cells an emoji, including flags and ZWJ
+// sequences, is measured as. Most terminals draw emoji two cells wide, the
+// default, but some draw them in one; SetEmojiWidth(1) realigns borders on
+// those. A width of 0 restores the default.
+//
+// Like SetAmbiguousWidth it is a terminal-specific tuning knob that should
+// be set once at startup. It applies to StringWidth and MeasureText, and so
+// to everything that measures through them.
+func SetEmojiWidth(width int) {
+	cellwidth.SetEmoji(width)
+}
+
+// clusterWidth returns the display width of a single grapheme cluster.
+func clusterWidth(cluster []rune) int {
+	return cellwidth.Cluster(cluster)
+}
+
+// tabWidth is the distance between the tab stops WrapCells expands tabs to.
+const tabWidth = 8
+
+// WrapCells splits text into lines at most width cells wide. Unlike
+// Renderer.WrapText it breaks anywhere rather than between words and keeps
+// whitespace, indentation and ANSI escape sequences intact, which suits
+// preformatted text such as logs and help output. Tabs are expanded to the
+// next multiple of eight cells. A width of zero or less only splits at
+// newlines.
+func WrapCells(text string, width int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if width <= 0 {
+			lines = append(lines, line)
+			continue
+		}
+
+		var current strings.Builder
+		cells := 0
+		for _, token := range tokenizeANSI(line) {
+			if token.escape {
+				current.WriteString(token.text)
+				continue
+			}
+
+			g := uniseg.NewGraphemes(token.text)
+			for g.Next() {
+				cluster := g.Str()
+				w := clusterWidth(g.Runes())
+				if cells+w > width && cells > 0 || cluster == "\t" && cells >= width {
+					lines = append(lines, current.String())
+					current.Reset()
+					cells = 0
+				}
+				if cluster == "\t" {
+					w = tabWidth - cells%tabWidth
+					if w > width-cells {
+						w = width - cells
+					}
+					cluster = strings.Repeat(" ", w)
+				}
+				current.WriteString(cluster)
+				cells += w
+			}
+		}
+		lines = append(lines, current.String())
+	}
+	return lines
+}
+
+// TruncateCells shortens text to at most width cells, ending it with "…".
+// It cuts between grapheme clusters, so an emoji ZWJ sequence or a flag is
+// never split, and, unlike runewidth.Truncate, it ignores ANSI escape
+// sequences when measuring and keeps all of them, so styles opened before
+// the cut are still closed. Text that fits is returned unchanged.
+func TruncateCells(text string, width int) string {
+	if MeasureText(text) <= width {
+		return text
+	}
+	budget := width - StringWidth("…")
+
+	var out strings.Builder
+	cells := 0
+	cut := false
+	for _, token := range tokenizeANSI(text) {
+		if token.escape {
+			out.WriteString(token.text)
+			continue
+		}
+		if cut {
+			continue
+		}
+		g := uniseg.NewGraphemes(token.text)
+		for g.Next() {
+			w := clusterWidth(g.Runes())
+			if cells+w > budget {
+				if budget >= 0 {
+					out.WriteString("…")
+				}
+				cut = true
+				break
+			}
+			out.WriteString(g.Str())
+			cells += w
+		}
+	}
+	return out.String()
+}
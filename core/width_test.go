@@ -0,0 +1,108 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestMeasureTextGraphemes(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+	}{
+		{"combining accent", "cafe\u0301", 4},
+		{"zero-width space", "a\u200bb", 2},
+		{"family ZWJ emoji", "\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466", 2},
+		{"skin tone", "\U0001F44D\U0001F3FD", 2},
+		{"flag", "\U0001F1EF\U0001F1F5", 2},
+		{"rainbow flag", "\U0001F3F3\uFE0F\u200d\U0001F308", 2},
+		{"styled", "\033[31mcafe\u0301\033[0m", 4},
+	}
+
+	for _, tt := range tests {
+		if got := MeasureText(tt.text); got != tt.width {
+			t.Errorf("%s: MeasureText(%q) = %d, want %d", tt.name, tt.text, got, tt.width)
+		}
+	}
+}
+
+func TestWrapCells(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"  indented line", 6, []string{"  inde", "nted l", "ine"}},
+		{"a\tb", 10, []string{"a       b"}},
+		{"部署状态", 5, []string{"部署", "状态"}},
+		{"\033[31mabcdef\033[0m", 4, []string{"\033[31mabcd", "ef\033[0m"}},
+		{"one\ntwo", 0, []string{"one", "two"}},
+	}
+
+	for _, tt := range tests {
+		got := WrapCells(tt.text, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("WrapCells(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWidthOverrides(t *testing.T) {
+	defer SetEmojiWidth(0)
+	defer SetAmbiguousWidth(!runewidth.DefaultCondition.EastAsianWidth)
+
+	SetEmojiWidth(1)
+	for _, text := range []string{"\U0001F600", "\U0001F1EF\U0001F1F5", "\u2764\uFE0F", "\U0001F44D\U0001F3FD"} {
+		if got := StringWidth(text); got != 1 {
+			t.Errorf("with emoji width 1, StringWidth(%q) = %d", text, got)
+		}
+	}
+	if got := StringWidth("a中"); got != 3 {
+		t.Errorf("emoji width should not affect CJK, got %d", got)
+	}
+
+	SetAmbiguousWidth(false)
+	if got := StringWidth("①"); got != 2 {
+		t.Errorf("wide ambiguous: StringWidth(①) = %d, want 2", got)
+	}
+	SetAmbiguousWidth(true)
+	if got := StringWidth("①"); got != 1 {
+		t.Errorf("narrow ambiguous: StringWidth(①) = %d, want 1", got)
+	}
+}
+
+func TestTruncateCells(t *testing.T) {
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466"
+	flag := "\U0001F1EF\U0001F1F5"
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"abcdef", 4, "abc…"},
+		{"部署状态", 5, "部署…"},
+		{family + family + "x", 4, family + "…"},
+		{flag + flag + flag, 5, flag + flag + "…"},
+		{"\033[31mabcdef\033[0m", 4, "\033[31mabc…\033[0m"},
+		{"abc", 0, ""},
+	}
+
+	for _, tt := range tests {
+		got := TruncateCells(tt.text, tt.width)
+		if got != tt.expected {
+			t.Errorf("TruncateCells(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.expected)
+		}
+		if MeasureText(got) > tt.width {
+			t.Errorf("TruncateCells(%q, %d) is %d cells wide", tt.text, tt.width, MeasureText(got))
+		}
+	}
+
+	// Renderer helpers and padding truncate the same way
+	if got := NewRenderer(0, 0).PadText(family+family, 3, AlignLeft); got != family+"…" {
+		t.Errorf("PadText = %q, want the cluster kept whole", got)
+	}
+}
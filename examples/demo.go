@@ -2,7 +2,7 @@
 package main
 
 import (
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/bagaking/cmdux"
@@ -36,7 +36,8 @@ func main() {
 		AddRow("Charlie", "Manager", "8 years").
 		HeaderStyle(app.Theme().Header).
 		RowStyle(app.Theme().Primary).
-		AltRowStyle(app.Theme().Secondary)
+		AltRowStyle(app.Theme().Secondary).
+		Striped(true)
 	app.Render(table)
 	app.Println("")
 
@@ -75,6 +76,7 @@ func main() {
 
 	// Example 6: Visual effects
 	app.Println("=== Effects Example ===", app.Theme().Header)
+	ux.SetEffectTheme(app.Theme())
 	ux.TypewriterEffect("This text appears character by character...", 50*time.Millisecond, app.Theme().Primary)
 	ux.RainbowEffect("🌈 This text has rainbow colors! 🌈")
 	app.Println("")
@@ -119,9 +121,8 @@ func main() {
 	app.Println("Registration complete! Here's your info:", app.Theme().Success)
 	infoBox := ui.NewBox().
 		Title("User Information").
-		Content(formatUserInfo(results)).
 		BorderStyle(app.Theme().Success)
-	app.Render(infoBox)
+	app.Render(addUserInfo(infoBox, results))
 
 	// Example 9: Theme showcase
 	app.Println("")
@@ -132,14 +133,17 @@ func main() {
 		"Dark":       style.DarkTheme(),
 		"Cyberpunk":  style.CyberpunkTheme(),
 		"Monochrome": style.MonochromeTheme(),
+		"Sunset":     style.SunsetTheme(),
+		"Accessible": style.AccessibleTheme(),
 	}
 	
+	// Configure a box once and render copies of it under each theme
+	showcase := ui.NewBox().Width(40)
 	for name, theme := range themes {
 		themeApp := cmdux.New(cmdux.WithTheme(theme))
-		themeBox := ui.NewBox().
+		themeBox := showcase.Clone().
 			Title(name + " Theme").
-			Content("This is how the " + name + " theme looks!").
-			Width(40)
+			Content("This is how the " + name + " theme looks!")
 		themeApp.Render(themeBox)
 		app.Println("")
 	}
@@ -148,28 +152,26 @@ func main() {
 	app.Println("🎉 cmdux demo complete! Build amazing CLI apps! 🚀", app.Theme().Success)
 }
 
-func formatUserInfo(results map[string]interface{}) string {
-	var info []string
-	
+// addUserInfo adds the registration results to box as aligned key/value lines.
+func addUserInfo(box *ui.Box, results map[string]interface{}) *ui.Box {
 	if username, ok := results["username"].(string); ok {
-		info = append(info, "Username: "+username)
+		box.KeyVal("Username", username)
 	}
 	if email, ok := results["email"].(string); ok {
-		info = append(info, "Email: "+email)
+		box.KeyVal("Email", email)
 	}
 	if age, ok := results["age"].(int); ok && age > 0 {
-		info = append(info, "Age: "+string(rune(age+'0')))
+		box.KeyVal("Age", strconv.Itoa(age))
 	}
 	if newsletter, ok := results["newsletter"].(bool); ok {
 		if newsletter {
-			info = append(info, "Newsletter: Yes")
+			box.KeyVal("Newsletter", "Yes")
 		} else {
-			info = append(info, "Newsletter: No")
+			box.KeyVal("Newsletter", "No")
 		}
 	}
 	if role, ok := results["role"].(string); ok {
-		info = append(info, "Role: "+role)
+		box.KeyVal("Role", role)
 	}
-	
-	return strings.Join(info, "\n")
+	return box
 }
\ No newline at end of file
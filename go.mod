@@ -4,12 +4,13 @@ go 1.21
 
 require (
 	github.com/fatih/color v1.16.0
+	github.com/mattn/go-isatty v0.0.20
 	github.com/mattn/go-runewidth v0.0.16
+	github.com/rivo/uniseg v0.2.0
+	golang.org/x/term v0.14.0
 )
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/rivo/uniseg v0.2.0 // indirect
 	golang.org/x/sys v0.14.0 // indirect
 )
@@ -0,0 +1,162 @@
+// Package input provides batch confirmation prompts.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// ConfirmResult is the answer to a ConfirmBatch prompt.
+type ConfirmResult int
+
+const (
+	// ConfirmYes applies the action to the current item.
+	ConfirmYes ConfirmResult = iota
+	// ConfirmNo skips the current item.
+	ConfirmNo
+	// ConfirmAll applies the action to this and all remaining items without asking.
+	ConfirmAll
+	// ConfirmQuit stops processing the remaining items.
+	ConfirmQuit
+)
+
+// String returns the answer's name.
+func (r ConfirmResult) String() string {
+	switch r {
+	case ConfirmYes:
+		return "yes"
+	case ConfirmNo:
+		return "no"
+	case ConfirmAll:
+		return "all"
+	case ConfirmQuit:
+		return "quit"
+	default:
+		return fmt.Sprintf("ConfirmResult(%d)", int(r))
+	}
+}
+
+// ConfirmWithPreview shows what an operation is about to do and then asks
+// for confirmation, e.g. a table of the files a command would delete:
+//
+//	ok, err := input.ConfirmWithPreview("Delete these files?", table, theme)
+//
+// The preview is rendered under theme with the prompt symbols and the
+// terminal width. Since the operation is assumed to be destructive, an
+// empty answer means no. A nil theme uses the default one.
+func ConfirmWithPreview(message string, preview core.Renderable, theme *style.Theme) (bool, error) {
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	if preview != nil {
+		ctx := core.NewRenderContext(theme)
+		ctx.Symbols = symbols
+		ctx.Width, _ = core.GetTerminalSize()
+		if output := strings.TrimRight(core.RenderWithContext(preview, ctx), "\n"); output != "" {
+			fmt.Fprintln(out(), output)
+		}
+	}
+	return Confirm(message, false)
+}
+
+// ConfirmBatch asks a yes/no/all/quit question for one item of a batch, like
+// `git add -p`. On a terminal a single key answers; otherwise a line is read.
+// Unrecognized answers print a short help and ask again.
+//
+//	for _, file := range files {
+//		answer, err := input.ConfirmBatch("Delete " + file + "?")
+//		...
+//		if answer == input.ConfirmAll { ... }
+//	}
+func ConfirmBatch(message string) (ConfirmResult, error) {
+	prompt := style.Primary.Sprint(symbols.Question+" "+message) + style.Muted.Sprint(" [y,n,a,q]") + ": "
+
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		return confirmBatchLine(prompt)
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return ConfirmNo, err
+	}
+	defer guard.Release()
+
+	keys := core.NewKeyReader(core.Stdin())
+	for {
+		fmt.Fprint(out(), prompt)
+
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			fmt.Fprint(out(), "\r\n")
+			return ConfirmNo, ErrAborted
+		}
+		if err != nil {
+			return ConfirmNo, err
+		}
+
+		switch key.Type {
+		case core.KeyCtrlC:
+			fmt.Fprint(out(), "\r\n")
+			return ConfirmNo, ErrCancelled
+		case core.KeyCtrlD:
+			fmt.Fprint(out(), "\r\n")
+			return ConfirmNo, ErrAborted
+		case core.KeyRune:
+			if result, ok := parseConfirmBatch(string(key.Rune)); ok {
+				fmt.Fprint(out(), string(key.Rune)+"\r\n")
+				return result, nil
+			}
+		}
+
+		fmt.Fprint(out(), "\r\n"+confirmBatchHelp("\r\n"))
+	}
+}
+
+// confirmBatchLine is the line-based ConfirmBatch used when stdin is not a terminal.
+func confirmBatchLine(prompt string) (ConfirmResult, error) {
+	reader := core.Stdin()
+	for {
+		fmt.Fprint(out(), prompt)
+
+		input, err := readLine(reader)
+		if err != nil {
+			return ConfirmNo, err
+		}
+
+		if result, ok := parseConfirmBatch(input); ok {
+			return result, nil
+		}
+		fmt.Fprint(out(), confirmBatchHelp("\n"))
+	}
+}
+
+// parseConfirmBatch maps an answer such as "y" or "all" to a result.
+func parseConfirmBatch(input string) (ConfirmResult, bool) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "y", "yes":
+		return ConfirmYes, true
+	case "n", "no":
+		return ConfirmNo, true
+	case "a", "all":
+		return ConfirmAll, true
+	case "q", "quit":
+		return ConfirmQuit, true
+	}
+	return ConfirmNo, false
+}
+
+// confirmBatchHelp lists the answers, ending lines with newline.
+func confirmBatchHelp(newline string) string {
+	lines := []string{
+		"y - yes, this item",
+		"n - no, skip this item",
+		"a - yes, this and all remaining items",
+		"q - quit, skip all remaining items",
+	}
+	return style.Muted.Sprint(strings.Join(lines, newline)) + newline
+}
@@ -0,0 +1,96 @@
+// Package input provides multi-line and external editor input.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// MultiLine reads lines until one equals terminator (e.g. ".") or the input
+// ends, and returns them joined with newlines. The terminator line itself is
+// not included. An empty terminator reads until EOF.
+func MultiLine(message, terminator string) (string, error) {
+	hint := " (end with Ctrl-D)"
+	if terminator != "" {
+		hint = fmt.Sprintf(" (end with %q on its own line)", terminator)
+	}
+	fmt.Fprintln(out(), style.Primary.Sprint(symbols.Question+" "+message)+style.Muted.Sprint(hint))
+
+	reader := core.Stdin()
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if terminator != "" && line == terminator {
+			break
+		}
+		if err == io.EOF {
+			if line != "" {
+				lines = append(lines, line)
+			}
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Editor opens the user's editor ($VISUAL, then $EDITOR, falling back to vi or
+// notepad) on a temporary file containing initial, waits for it to exit and
+// returns the file's contents.
+func Editor(initial string) (string, error) {
+	file, err := os.CreateTemp("", "cmdux-*.txt")
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(initial); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	// The editor setting may include arguments, e.g. "code --wait"
+	args := append(strings.Fields(editorCommand()), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %v", args[0], err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
@@ -2,8 +2,12 @@
 package input
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,25 +16,55 @@ import (
 
 // Form represents a collection of input fields.
 type Form struct {
-	title       string
-	fields      []FormField
-	titleStyle  *style.Color
-	labelStyle  *style.Color
-	inputStyle  *style.Color
-	errorStyle  *style.Color
-	results     map[string]interface{}
+	title      string
+	fields     []FormField
+	titleStyle *style.Color
+	labelStyle *style.Color
+	inputStyle *style.Color
+	errorStyle *style.Color
+	helpStyle  *style.Color
+	results    map[string]interface{}
+	answers    map[string]interface{}
+	failFast   bool
+}
+
+// FormErrors maps field names to the problems found with their answers. A
+// form in non-fail-fast mode returns it from Run so every invalid field can
+// be reported together; retrieve it with errors.As.
+type FormErrors map[string]error
+
+// Error lists the invalid fields and their problems, sorted by field name.
+func (e FormErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, len(names))
+	for i, name := range names {
+		problems[i] = fmt.Sprintf("%s: %v", name, e[name])
+	}
+	return fmt.Sprintf("%d invalid form fields: %s", len(e), strings.Join(problems, "; "))
 }
 
 // FormField represents a single form field.
 type FormField struct {
-	Name        string
-	Label       string
-	Type        FieldType
-	Required    bool
-	Default     interface{}
+	Name     string
+	Label    string
+	Help     string // Optional explanation shown above the prompt
+	Type     FieldType
+	Required bool
+	Default  interface{}
+	// DefaultFunc computes the default just before the field is prompted,
+	// e.g. the current directory or time, and takes precedence over Default.
+	DefaultFunc func() interface{}
 	Options     []string
 	Validator   func(interface{}) error
-	Transformer func(string) interface{}
+	// AsyncValidator validates text fields with a long-running check, such
+	// as a network lookup, while a spinner is shown. See Prompt.AsyncValidator.
+	AsyncValidator func(ctx context.Context, value string) error
+	Transformer    func(string) interface{}
 }
 
 // FieldType represents the type of form field.
@@ -54,7 +88,9 @@ func NewForm(title string) *Form {
 		labelStyle: style.Secondary,
 		inputStyle: style.Primary,
 		errorStyle: style.Error,
+		helpStyle:  style.Muted,
 		results:    make(map[string]interface{}),
+		failFast:   true,
 	}
 }
 
@@ -64,6 +100,24 @@ func (f *Form) AddField(field FormField) *Form {
 	return f
 }
 
+// Help sets the help text of the most recently added field.
+func (f *Form) Help(help string) *Form {
+	if len(f.fields) > 0 {
+		f.fields[len(f.fields)-1].Help = help
+	}
+	return f
+}
+
+// DefaultFunc sets a function computing the default of the most recently
+// added field when it is prompted, instead of when the form is built. See
+// FormField.DefaultFunc.
+func (f *Form) DefaultFunc(fn func() interface{}) *Form {
+	if len(f.fields) > 0 {
+		f.fields[len(f.fields)-1].DefaultFunc = fn
+	}
+	return f
+}
+
 // TextField adds a text input field.
 func (f *Form) TextField(name, label string, required bool, defaultValue ...string) *Form {
 	field := FormField{
@@ -72,11 +126,11 @@ func (f *Form) TextField(name, label string, required bool, defaultValue ...stri
 		Type:     FieldTypeText,
 		Required: required,
 	}
-	
+
 	if len(defaultValue) > 0 {
 		field.Default = defaultValue[0]
 	}
-	
+
 	return f.AddField(field)
 }
 
@@ -88,7 +142,7 @@ func (f *Form) PasswordField(name, label string, required bool) *Form {
 		Type:     FieldTypePassword,
 		Required: required,
 	}
-	
+
 	return f.AddField(field)
 }
 
@@ -100,11 +154,11 @@ func (f *Form) NumberField(name, label string, required bool, defaultValue ...in
 		Type:     FieldTypeNumber,
 		Required: required,
 	}
-	
+
 	if len(defaultValue) > 0 {
 		field.Default = defaultValue[0]
 	}
-	
+
 	return f.AddField(field)
 }
 
@@ -116,11 +170,11 @@ func (f *Form) BooleanField(name, label string, defaultValue ...bool) *Form {
 		Type:     FieldTypeBoolean,
 		Required: false, // Boolean fields are never required
 	}
-	
+
 	if len(defaultValue) > 0 {
 		field.Default = defaultValue[0]
 	}
-	
+
 	return f.AddField(field)
 }
 
@@ -133,7 +187,7 @@ func (f *Form) SelectField(name, label string, options []string, required bool)
 		Required: required,
 		Options:  options,
 	}
-	
+
 	return f.AddField(field)
 }
 
@@ -146,34 +200,291 @@ func (f *Form) MultiSelectField(name, label string, options []string) *Form {
 		Required: false,
 		Options:  options,
 	}
-	
+
 	return f.AddField(field)
 }
 
-// Run executes the form and collects all input.
+// WithAnswers pre-seeds answers by field name. Run uses them instead of
+// prompting, after checking them like typed input, so a form can be filled
+// partly or entirely without a terminal, e.g. in CI. Values may have the
+// field's result type or the types JSON decodes to: numbers as float64,
+// lists as []interface{}, and strings for any field.
+func (f *Form) WithAnswers(answers map[string]interface{}) *Form {
+	if f.answers == nil {
+		f.answers = make(map[string]interface{}, len(answers))
+	}
+	for name, value := range answers {
+		f.answers[name] = value
+	}
+	return f
+}
+
+// ImportJSON pre-seeds answers from a JSON object, such as one written by
+// ExportJSON. See WithAnswers.
+func (f *Form) ImportJSON(data []byte) error {
+	var answers map[string]interface{}
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return fmt.Errorf("invalid form answers: %w", err)
+	}
+	f.WithAnswers(answers)
+	return nil
+}
+
+// ExportJSON returns the collected answers as a JSON object keyed by field
+// name, for replay with ImportJSON. Password fields are left out so secrets
+// don't end up in recorded files; supply them with WithAnswers instead.
+func (f *Form) ExportJSON() ([]byte, error) {
+	export := make(map[string]interface{}, len(f.results))
+	for _, field := range f.fields {
+		if value, ok := f.results[field.Name]; ok && field.Type != FieldTypePassword {
+			export[field.Name] = value
+		}
+	}
+	return json.MarshalIndent(export, "", "  ")
+}
+
+// FailFast controls how invalid answers are handled. By default (true) a
+// prompted field asks again until its answer is valid, and an invalid
+// pre-seeded answer stops the form. With false every field is asked once:
+// invalid answers are recorded instead, the remaining fields are still
+// collected, and Run returns the valid results together with a FormErrors
+// describing the rest. This suits full validation reports, e.g. for answers
+// loaded with ImportJSON. Cancelling or ending the input still stops the form.
+func (f *Form) FailFast(failFast bool) *Form {
+	f.failFast = failFast
+	return f
+}
+
+// Run executes the form and collects all input. When it fails, e.g. because
+// the user cancelled, it still returns the answers collected so far, keyed
+// by field name, so callers can log or resume them; that map may be
+// incomplete and must be checked before use. Every run starts without
+// answers, so a run that fails early does not return an earlier run's.
 func (f *Form) Run() (map[string]interface{}, error) {
+	return f.RunContext(context.Background())
+}
+
+// RunContext is like Run but aborts with the context's error once ctx is
+// cancelled, including during async validation.
+func (f *Form) RunContext(ctx context.Context) (map[string]interface{}, error) {
+	f.results = make(map[string]interface{})
+
 	// Display form title
 	if f.title != "" {
-		fmt.Println(f.titleStyle.Sprint("=== " + f.title + " ==="))
-		fmt.Println()
+		fmt.Fprintln(out(), f.titleStyle.Sprint("=== "+f.title+" ==="))
+		fmt.Fprintln(out())
 	}
-	
-	// Process each field
+
+	// Process each field, using pre-seeded answers where given
+	fieldErrors := FormErrors{}
 	for _, field := range f.fields {
-		value, err := f.processField(field)
+		if answer, ok := f.answers[field.Name]; ok {
+			value, err := f.applyAnswer(ctx, field, answer)
+			if err != nil && f.failFast {
+				return f.results, fmt.Errorf("answer for %q: %w", field.Name, err)
+			}
+			if err != nil {
+				fieldErrors[field.Name] = err
+				continue
+			}
+			f.results[field.Name] = value
+			continue
+		}
+
+		if !f.failFast {
+			value, err := f.collectField(ctx, field)
+			if errors.Is(err, ErrCancelled) || errors.Is(err, ErrAborted) || ctx.Err() != nil {
+				return f.results, err
+			}
+			if err != nil {
+				fmt.Fprintln(out(), f.errorStyle.Sprint(symbols.Error+" "+err.Error()))
+				fieldErrors[field.Name] = err
+				continue
+			}
+			f.results[field.Name] = value
+			continue
+		}
+
+		value, err := f.processField(ctx, field)
 		if err != nil {
-			return nil, err
+			return f.results, err
 		}
 		f.results[field.Name] = value
 	}
-	
+
+	if len(fieldErrors) > 0 {
+		return f.results, fieldErrors
+	}
 	return f.results, nil
 }
 
-func (f *Form) processField(field FormField) (interface{}, error) {
+// collectField asks for a field once, without re-prompting, and checks the
+// answer like a pre-seeded one. It is used when FailFast is off.
+func (f *Form) collectField(ctx context.Context, field FormField) (interface{}, error) {
+	switch field.Type {
+	case FieldTypeText, FieldTypePassword, FieldTypeNumber:
+	default:
+		// Choices and yes/no answers have no validators to skip
+		return f.processField(ctx, field)
+	}
+	field = resolveDefault(field)
+
+	if field.Help != "" {
+		fmt.Fprintln(out(), f.helpStyle.Sprint("  "+field.Help))
+	}
+
+	prompt := NewPrompt(field.Label)
+	switch defaultValue := field.Default.(type) {
+	case string:
+		prompt.Default(defaultValue)
+	case int:
+		prompt.Default(strconv.Itoa(defaultValue))
+	}
+	if field.Type == FieldTypePassword {
+		prompt.Hidden(true).Trim(false)
+	}
+
+	input, err := prompt.RunContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if field.Type == FieldTypeNumber && input == "" {
+		if field.Required {
+			return nil, errRequired
+		}
+		return 0, nil
+	}
+	return f.applyAnswer(ctx, field, input)
+}
+
+// applyAnswer converts a pre-seeded answer to the field's result type and
+// checks it the way typed input would be checked.
+func (f *Form) applyAnswer(ctx context.Context, field FormField, answer interface{}) (interface{}, error) {
+	var value interface{}
+	switch field.Type {
+	case FieldTypeText, FieldTypePassword:
+		text, ok := answer.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", answer)
+		}
+		if field.Required && strings.TrimSpace(text) == "" {
+			return nil, errRequired
+		}
+		if field.Transformer != nil {
+			if str, ok := field.Transformer(text).(string); ok {
+				text = str
+			}
+		}
+		value = text
+	case FieldTypeNumber:
+		switch n := answer.(type) {
+		case int:
+			value = n
+		case float64:
+			if n != float64(int(n)) {
+				return nil, fmt.Errorf("expected a whole number, got %v", n)
+			}
+			value = int(n)
+		case string:
+			parsed, err := parseInt(n)
+			if err != nil {
+				return nil, err
+			}
+			value = parsed
+		default:
+			return nil, fmt.Errorf("expected a number, got %T", answer)
+		}
+	case FieldTypeBoolean:
+		switch b := answer.(type) {
+		case bool:
+			value = b
+		case string:
+			parsed, err := parseBool(b)
+			if err != nil {
+				return nil, err
+			}
+			value = parsed
+		default:
+			return nil, fmt.Errorf("expected a yes/no answer, got %T", answer)
+		}
+	case FieldTypeSelect:
+		choice, ok := answer.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", answer)
+		}
+		option, err := matchOption(field.Options, choice)
+		if err != nil {
+			return nil, err
+		}
+		value = option
+	case FieldTypeMultiSelect:
+		var choices []string
+		switch list := answer.(type) {
+		case []string:
+			choices = list
+		case []interface{}:
+			for _, item := range list {
+				choice, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected a list of strings, got %T in it", item)
+				}
+				choices = append(choices, choice)
+			}
+		default:
+			return nil, fmt.Errorf("expected a list of strings, got %T", answer)
+		}
+		selected := make([]string, 0, len(choices))
+		for _, choice := range choices {
+			option, err := matchOption(field.Options, choice)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, option)
+		}
+		value = selected
+	default:
+		return nil, fmt.Errorf("unknown field type: %v", field.Type)
+	}
+
+	if field.Validator != nil {
+		if err := field.Validator(value); err != nil {
+			return nil, err
+		}
+	}
+	if text, ok := value.(string); ok && field.AsyncValidator != nil {
+		if err := field.AsyncValidator(ctx, text); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// matchOption returns the option that choice names, matched with findOption.
+func matchOption(options []string, choice string) (string, error) {
+	index := findOption(options, strings.TrimSpace(choice))
+	if index < 0 {
+		return "", fmt.Errorf("%q is not one of %s", choice, strings.Join(options, ", "))
+	}
+	return options[index], nil
+}
+
+// resolveDefault returns field with Default set from DefaultFunc, if any.
+func resolveDefault(field FormField) FormField {
+	if field.DefaultFunc != nil {
+		field.Default = field.DefaultFunc()
+	}
+	return field
+}
+
+func (f *Form) processField(ctx context.Context, field FormField) (interface{}, error) {
+	field = resolveDefault(field)
+	if field.Help != "" {
+		fmt.Fprintln(out(), f.helpStyle.Sprint("  "+field.Help))
+	}
+
 	switch field.Type {
 	case FieldTypeText:
-		return f.processTextField(field)
+		return f.processTextField(ctx, field)
 	case FieldTypePassword:
 		return f.processPasswordField(field)
 	case FieldTypeNumber:
@@ -189,22 +500,26 @@ func (f *Form) processField(field FormField) (interface{}, error) {
 	}
 }
 
-func (f *Form) processTextField(field FormField) (string, error) {
+func (f *Form) processTextField(ctx context.Context, field FormField) (string, error) {
 	prompt := NewPrompt(field.Label).
 		Required(field.Required)
-	
+
 	if field.Default != nil {
 		if defaultStr, ok := field.Default.(string); ok {
 			prompt.Default(defaultStr)
 		}
 	}
-	
+
 	if field.Validator != nil {
 		prompt.Validator(func(input string) error {
 			return field.Validator(input)
 		})
 	}
-	
+
+	if field.AsyncValidator != nil {
+		prompt.AsyncValidator(field.AsyncValidator)
+	}
+
 	if field.Transformer != nil {
 		prompt.Transformer(func(input string) string {
 			if result := field.Transformer(input); result != nil {
@@ -215,8 +530,8 @@ func (f *Form) processTextField(field FormField) (string, error) {
 			return input
 		})
 	}
-	
-	return prompt.Run()
+
+	return prompt.RunContext(ctx)
 }
 
 func (f *Form) processPasswordField(field FormField) (string, error) {
@@ -230,21 +545,21 @@ func (f *Form) processNumberField(field FormField) (int, error) {
 			if input == "" && !field.Required {
 				return nil
 			}
-			_, err := strconv.Atoi(input)
+			_, err := parseInt(input)
 			return err
 		})
-	
+
 	if field.Default != nil {
 		if defaultInt, ok := field.Default.(int); ok {
 			prompt.Default(strconv.Itoa(defaultInt))
 		}
 	}
-	
+
 	input, err := prompt.Run()
 	if err != nil {
 		return 0, err
 	}
-	
+
 	if input == "" {
 		if field.Default != nil {
 			if defaultInt, ok := field.Default.(int); ok {
@@ -253,7 +568,7 @@ func (f *Form) processNumberField(field FormField) (int, error) {
 		}
 		return 0, nil
 	}
-	
+
 	return strconv.Atoi(input)
 }
 
@@ -264,7 +579,7 @@ func (f *Form) processBooleanField(field FormField) (bool, error) {
 			defaultVal = defaultBool
 		}
 	}
-	
+
 	return Confirm(field.Label, defaultVal)
 }
 
@@ -321,47 +636,47 @@ func (f *Form) Bind(target interface{}) error {
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("target must be a pointer to a struct")
 	}
-	
+
 	v = v.Elem()
 	t := v.Type()
-	
+
 	for i := 0; i < v.NumField(); i++ {
 		field := t.Field(i)
 		fieldValue := v.Field(i)
-		
+
 		if !fieldValue.CanSet() {
 			continue
 		}
-		
+
 		// Look for form tag or use field name
 		name := field.Tag.Get("form")
 		if name == "" {
 			name = strings.ToLower(field.Name)
 		}
-		
+
 		if result, exists := f.results[name]; exists {
 			if err := f.setFieldValue(fieldValue, result); err != nil {
 				return fmt.Errorf("error setting field %s: %v", name, err)
 			}
 		}
 	}
-	
+
 	return nil
 }
 
 func (f *Form) setFieldValue(fieldValue reflect.Value, result interface{}) error {
 	resultValue := reflect.ValueOf(result)
-	
+
 	if resultValue.Type().AssignableTo(fieldValue.Type()) {
 		fieldValue.Set(resultValue)
 		return nil
 	}
-	
+
 	// Try type conversion
 	if resultValue.Type().ConvertibleTo(fieldValue.Type()) {
 		fieldValue.Set(resultValue.Convert(fieldValue.Type()))
 		return nil
 	}
-	
+
 	return fmt.Errorf("cannot assign %T to %T", result, fieldValue.Interface())
-}
\ No newline at end of file
+}
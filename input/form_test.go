@@ -0,0 +1,280 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagaking/cmdux/ux"
+)
+
+func newReplayForm() *Form {
+	return NewForm("").
+		TextField("username", "Username", true).
+		PasswordField("password", "Password", true).
+		NumberField("age", "Age", false).
+		BooleanField("newsletter", "Subscribe?").
+		SelectField("role", "Role", []string{"Developer", "Designer"}, true).
+		MultiSelectField("tags", "Tags", []string{"go", "rust", "zig"})
+}
+
+func TestFormWithAnswersReplaysExport(t *testing.T) {
+	form := newReplayForm().WithAnswers(map[string]interface{}{
+		"username":   "alice",
+		"password":   "s3cret",
+		"age":        30,
+		"newsletter": true,
+		"role":       "developer",
+		"tags":       []string{"Go", "zig"},
+	})
+	results, err := form.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["role"] != "Developer" || !reflect.DeepEqual(results["tags"], []string{"go", "zig"}) {
+		t.Errorf("expected options matched to their canonical text, got %v", results)
+	}
+
+	data, err := form.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("password leaked into export: %s", data)
+	}
+
+	replay := newReplayForm()
+	if err := replay.ImportJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := replay.WithAnswers(map[string]interface{}{"password": "s3cret"}).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayed, results) {
+		t.Errorf("replay differs:\n got %v\nwant %v", replayed, results)
+	}
+}
+
+func TestFormWithAnswersValidates(t *testing.T) {
+	tooYoung := errors.New("must be 18 or older")
+	tests := []struct {
+		name   string
+		field  string
+		answer interface{}
+		want   string
+	}{
+		{"unparsable number", "age", "thirty", `answer for "age"`},
+		{"fractional number", "age", 30.5, "whole number"},
+		{"unsupported type", "age", json.Number("30"), "expected a number"},
+		{"unknown option", "role", "Tester", "not one of"},
+		{"empty required", "username", " ", "required"},
+		{"validator", "age", 12.0, tooYoung.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := map[string]interface{}{
+				"username": "alice", "password": "s3cret", "age": 30,
+				"newsletter": false, "role": "Designer", "tags": []interface{}{"rust"},
+			}
+			answers[tt.field] = tt.answer
+
+			form := newReplayForm()
+			form.fields[2].Validator = func(v interface{}) error {
+				if v.(int) < 18 {
+					return tooYoung
+				}
+				return nil
+			}
+			_, err := form.WithAnswers(answers).Run()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFormCollectsAllErrors(t *testing.T) {
+	form := newReplayForm().FailFast(false).WithAnswers(map[string]interface{}{
+		"username": " ", "password": "s3cret",
+		"newsletter": true, "role": "Tester", "tags": []interface{}{"go"},
+	})
+
+	// The unanswered age is prompted for once; its invalid input is recorded
+	// rather than asked again
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdin = r
+	w.WriteString("thirty\n")
+	w.Close()
+
+	results, err := form.Run()
+	var formErrors FormErrors
+	if !errors.As(err, &formErrors) {
+		t.Fatalf("expected FormErrors, got %v", err)
+	}
+	if len(formErrors) != 3 || formErrors["username"] == nil || formErrors["age"] == nil || formErrors["role"] == nil {
+		t.Errorf("expected errors for username, age and role, got %v", formErrors)
+	}
+	if results["newsletter"] != true || !reflect.DeepEqual(results["tags"], []string{"go"}) {
+		t.Errorf("expected the valid answers in the results, got %v", results)
+	}
+	if _, ok := results["role"]; ok {
+		t.Errorf("invalid answers should be left out of the results, got %v", results)
+	}
+}
+
+func TestFormDefaultFunc(t *testing.T) {
+	calls := 0
+	form := NewForm("Settings").
+		TextField("dir", "Directory", false, "stale").
+		DefaultFunc(func() interface{} {
+			calls++
+			return "/srv/app"
+		})
+	if calls != 0 {
+		t.Fatalf("DefaultFunc called before the form ran")
+	}
+
+	withStdin(t, "\n", func() {
+		results, err := form.Run()
+		if err != nil || results["dir"] != "/srv/app" {
+			t.Errorf("expected the computed default, got %v, %v", results, err)
+		}
+	})
+	if calls != 1 {
+		t.Errorf("DefaultFunc called %d times, want 1", calls)
+	}
+
+	form = NewForm("Settings").
+		NumberField("port", "Port", false).
+		DefaultFunc(func() interface{} { return 8080 })
+	withStdin(t, "\n", func() {
+		results, err := form.Run()
+		if err != nil || results["port"] != 8080 {
+			t.Errorf("expected the computed default, got %v, %v", results, err)
+		}
+	})
+}
+
+func TestFormRunReturnsPartialResults(t *testing.T) {
+	form := newReplayForm().WithAnswers(map[string]interface{}{
+		"username": "alice", "password": "s3cret", "age": "old",
+	})
+
+	results, err := form.Run()
+	if err == nil {
+		t.Fatal("expected an error for the invalid age")
+	}
+	if results["username"] != "alice" || results["password"] != "s3cret" {
+		t.Errorf("expected the answers before the error, got %v", results)
+	}
+	if _, ok := results["age"]; ok {
+		t.Errorf("the invalid answer should not be in the results, got %v", results)
+	}
+}
+
+func newAsyncForm(validator func(ctx context.Context, value string) error) *Form {
+	return NewForm("").AddField(FormField{
+		Name:           "username",
+		Label:          "Username",
+		Type:           FieldTypeText,
+		Required:       true,
+		AsyncValidator: validator,
+	})
+}
+
+func TestFormAsyncValidator(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	ux.SetOutput(io.Discard)
+	defer func() {
+		SetOutput(nil)
+		ux.SetOutput(nil)
+	}()
+
+	var checked []string
+	slowCheck := func(ctx context.Context, value string) error {
+		checked = append(checked, value)
+		select {
+		case <-time.After(20 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if value == "admin" {
+			return errors.New("username is taken")
+		}
+		return nil
+	}
+
+	withStdin(t, "admin\nalice\n", func() {
+		results, err := newAsyncForm(slowCheck).Run()
+		if err != nil || results["username"] != "alice" {
+			t.Errorf("Run = %v, %v; want the second answer", results, err)
+		}
+	})
+	if strings.Join(checked, ",") != "admin,alice" {
+		t.Errorf("expected both answers to be checked, got %v", checked)
+	}
+	if !strings.Contains(buf.String(), "username is taken") {
+		t.Errorf("expected the rejection to be shown, got %q", buf.String())
+	}
+
+	// Without FailFast the rejection is collected instead of re-prompting
+	withStdin(t, "admin\n", func() {
+		_, err := newAsyncForm(slowCheck).FailFast(false).Run()
+		var fieldErrors FormErrors
+		if !errors.As(err, &fieldErrors) || fieldErrors["username"] == nil {
+			t.Errorf("expected a field error for username, got %v", err)
+		}
+	})
+}
+
+func TestFormRunContextCancelled(t *testing.T) {
+	SetOutput(io.Discard)
+	ux.SetOutput(io.Discard)
+	defer func() {
+		SetOutput(nil)
+		ux.SetOutput(nil)
+	}()
+
+	// A validator that ignores its context is abandoned on cancel
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	stuck := func(context.Context, string) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	withStdin(t, "alice\n", func() {
+		start := time.Now()
+		_, err := newAsyncForm(stuck).RunContext(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the deadline error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("RunContext waited %v for the validator", elapsed)
+		}
+	})
+
+	// An already cancelled context stops before the first read
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	withStdin(t, "alice\n", func() {
+		results, err := newAsyncForm(nil).RunContext(cancelled)
+		if !errors.Is(err, context.Canceled) || len(results) != 0 {
+			t.Errorf("RunContext = %v, %v; want no results and context.Canceled", results, err)
+		}
+	})
+}
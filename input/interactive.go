@@ -0,0 +1,102 @@
+// Package input provides keyboard-driven interactive components.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// MultiSelectInteractive shows a checkbox list: arrow keys move the cursor,
+// space toggles the current option, and Enter confirms. Options listed in
+// initial start out checked. When stdin is not a terminal it falls back to
+// the numeric MultiSelect prompt with the same initial selection.
+func MultiSelectInteractive(message string, options []string, initial ...int) ([]int, []string, error) {
+	if len(options) == 0 {
+		return nil, nil, fmt.Errorf("no options provided")
+	}
+
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		return MultiSelect(message, options, initial...)
+	}
+
+	checked := make([]bool, len(options))
+	for _, index := range initialChoices(options, initial) {
+		checked[index] = true
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return nil, nil, err
+	}
+	defer guard.Release()
+
+	keys := core.NewKeyReader(core.Stdin())
+	screen := &core.LiveRegion{Writer: out()}
+	cursor := 0
+
+	for {
+		screen.Draw(multiSelectLines(message, options, checked, cursor))
+
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			return nil, nil, ErrAborted
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case key.Type == core.KeyUp || key.Type == core.KeyRune && key.Rune == 'k':
+			cursor = (cursor - 1 + len(options)) % len(options)
+		case key.Type == core.KeyDown || key.Type == core.KeyRune && key.Rune == 'j':
+			cursor = (cursor + 1) % len(options)
+		case key.Type == core.KeyRune && key.Rune == ' ':
+			checked[cursor] = !checked[cursor]
+		case key.Type == core.KeyEnter:
+			var indices []int
+			var selected []string
+			for i, isChecked := range checked {
+				if isChecked {
+					indices = append(indices, i)
+					selected = append(selected, options[i])
+				}
+			}
+			fmt.Fprint(out(), "\r\n")
+			return indices, selected, nil
+		case key.Type == core.KeyCtrlC || key.Type == core.KeyEscape:
+			fmt.Fprint(out(), "\r\n")
+			return nil, nil, ErrCancelled
+		case key.Type == core.KeyCtrlD:
+			fmt.Fprint(out(), "\r\n")
+			return nil, nil, ErrAborted
+		}
+	}
+}
+
+func multiSelectLines(message string, options []string, checked []bool, cursor int) []string {
+	lines := []string{
+		style.Primary.Sprint(symbols.Question+" "+message) + style.Muted.Sprint(" (space to toggle, enter to confirm)"),
+	}
+
+	count := 0
+	for i, option := range options {
+		box := "[ ]"
+		if checked[i] {
+			box = "[x]"
+			count++
+		}
+
+		if i == cursor {
+			lines = append(lines, style.Selected.Sprint(symbols.Selected+" "+box+" "+option))
+		} else {
+			lines = append(lines, "  "+box+" "+option)
+		}
+	}
+
+	lines = append(lines, style.Muted.Sprintf("  %d selected", count))
+	return lines
+}
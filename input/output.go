@@ -0,0 +1,26 @@
+// Package input provides the output sink prompts draw on.
+package input
+
+import (
+	"io"
+	"os"
+)
+
+// output replaces os.Stdout as the destination of prompts; see SetOutput.
+var output io.Writer
+
+// SetOutput sets where prompts, forms and wizards draw, os.Stdout by
+// default. Answers are still read from stdin, so with io.Discard a script
+// can feed a CLI its answers without any prompt text in the output. A nil
+// writer restores os.Stdout.
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// out returns the writer prompts draw on.
+func out() io.Writer {
+	if output != nil {
+		return output
+	}
+	return os.Stdout
+}
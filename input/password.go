@@ -0,0 +1,104 @@
+// Package input provides password length and strength indicators.
+package input
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bagaking/cmdux/style"
+)
+
+// PasswordIndicator selects the feedback shown after hidden input while it is
+// typed. Neither kind reveals any characters.
+type PasswordIndicator int
+
+const (
+	// IndicatorNone shows only the masked input. This is the default.
+	IndicatorNone PasswordIndicator = iota
+	// IndicatorLength shows the number of characters entered, e.g. "***** (5)".
+	IndicatorLength
+	// IndicatorStrength shows a four-step bar and a rating from "very weak"
+	// to "strong", for password-creation prompts.
+	IndicatorStrength
+)
+
+// strengthLabels names the scores returned by passwordStrength.
+var strengthLabels = []string{"very weak", "weak", "fair", "good", "strong"}
+
+// Indicator shows a length or strength indicator after hidden input while it
+// is typed on a terminal. Characters are counted as runes, so multi-byte
+// characters count once. It only applies to Hidden prompts reading from a
+// terminal.
+func (p *Prompt) Indicator(indicator PasswordIndicator) *Prompt {
+	p.indicator = indicator
+	return p
+}
+
+// passwordIndicator returns the indicator text shown after the masked input.
+func passwordIndicator(indicator PasswordIndicator, input []rune) string {
+	switch indicator {
+	case IndicatorLength:
+		return style.Muted.Sprintf(" (%d)", len(input))
+	case IndicatorStrength:
+		if len(input) == 0 {
+			return ""
+		}
+		score := passwordStrength(input)
+		color := style.Error
+		switch {
+		case score >= 3:
+			color = style.Success
+		case score == 2:
+			color = style.Warning
+		}
+		bar := color.Sprint(strings.Repeat("█", score)) + style.Muted.Sprint(strings.Repeat("░", len(strengthLabels)-1-score))
+		return fmt.Sprintf(" %s %s", bar, color.Sprint(strengthLabels[score]))
+	}
+	return ""
+}
+
+// passwordStrength rates a password from 0 (very weak) to 4 (strong) by its
+// length in runes and how many kinds of character it mixes: lower case,
+// upper case, digits and others. Passwords shorter than eight characters
+// rate weak at best.
+func passwordStrength(password []rune) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	kinds := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			kinds++
+		}
+	}
+
+	score := 0
+	if len(password) >= 8 {
+		score++
+	}
+	if len(password) >= 12 {
+		score++
+	}
+	if kinds >= 2 {
+		score++
+	}
+	if kinds >= 3 {
+		score++
+	}
+	if len(password) < 8 && score > 1 {
+		score = 1
+	}
+	return score
+}
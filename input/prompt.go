@@ -3,32 +3,98 @@ package input
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
+	"github.com/bagaking/cmdux/ux"
 )
 
+// ErrCancelled is returned when the user cancels an interactive input with Ctrl-C.
+// It is core.ErrCancelled, shared with interactive components in other packages.
+var ErrCancelled = core.ErrCancelled
+
+// ErrAborted is returned when the input ends (Ctrl-D or a closed stdin) before
+// an answer was given. Prompts never re-prompt after EOF, so interactive loops
+// can check for ErrAborted to exit cleanly. A final line without a trailing
+// newline is still accepted as an answer.
+var ErrAborted = core.ErrAborted
+
+// asyncSpinnerDelay keeps quick async validations from flashing a spinner.
+const asyncSpinnerDelay = 150 * time.Millisecond
+
+// symbols provides the glyphs used by prompts; see SetSymbols.
+var symbols = style.DefaultSymbols()
+
+// SetSymbols sets the symbol set used for prompt glyphs such as the question
+// prefix and error marker, e.g. style.ASCIISymbols() for plain terminals.
+// It affects prompts created afterwards.
+func SetSymbols(set style.SymbolSet) {
+	symbols = set
+}
+
+// optionMatcher resolves typed option names in Select and MultiSelect; see
+// SetOptionMatcher. It is guarded by optionMatcherMu.
+var (
+	optionMatcherMu sync.RWMutex
+	optionMatcher   core.Matcher = core.FoldMatch
+)
+
+// SetOptionMatcher sets how Select and MultiSelect match an answer typed as
+// option text rather than a number. The default, core.FoldMatch, ignores case
+// and extra whitespace; nil requires an exact match.
+func SetOptionMatcher(match core.Matcher) {
+	optionMatcherMu.Lock()
+	optionMatcher = match
+	optionMatcherMu.Unlock()
+}
+
+// findOption returns the index of the option input names, matched with the
+// matcher set by SetOptionMatcher, or -1.
+func findOption(options []string, input string) int {
+	optionMatcherMu.RLock()
+	match := optionMatcher
+	optionMatcherMu.RUnlock()
+	return core.FindOption(options, input, match)
+}
+
+// errRequired rejects an empty answer to a required prompt or form field.
+var errRequired = errors.New("this field is required")
+
 // Prompt represents an interactive user prompt.
 type Prompt struct {
-	message     string
-	defaultValue string
-	validator   func(string) error
-	transformer func(string) string
-	required    bool
-	hidden      bool // For password input
-	prefix      string
-	style       *style.Color
-	errorStyle  *style.Color
+	message        string
+	defaultValue   string
+	validator      func(string) error
+	asyncValidator func(context.Context, string) error
+	transformer    func(string) string
+	normalizer     func(string) (string, error)
+	required       bool
+	hidden         bool // For password input
+	indicator      PasswordIndicator
+	liveValidate   bool
+	trim           bool
+	prefix         string
+	symbols        style.SymbolSet
+	style          *style.Color
+	errorStyle     *style.Color
 }
 
 // NewPrompt creates a new prompt.
 func NewPrompt(message string) *Prompt {
 	return &Prompt{
 		message:    message,
-		prefix:     "? ",
+		prefix:     symbols.Question + " ",
+		symbols:    symbols,
+		trim:       true,
 		style:      style.Primary,
 		errorStyle: style.Error,
 	}
@@ -52,13 +118,51 @@ func (p *Prompt) Hidden(hidden bool) *Prompt {
 	return p
 }
 
-// Validator sets a validation function.
+// Trim controls whether leading and trailing whitespace is removed from the
+// input (default true). The line terminator is always removed.
+func (p *Prompt) Trim(trim bool) *Prompt {
+	p.trim = trim
+	return p
+}
+
+// Validator sets a validation function. To validate and canonicalize input
+// in one step, use Normalize.
 func (p *Prompt) Validator(validator func(string) error) *Prompt {
 	p.validator = validator
 	return p
 }
 
-// Transformer sets a transformation function applied to the input.
+// LiveValidate runs the validator on every keystroke when stdin is a terminal,
+// showing a hint below the input: a check mark once the input is valid, or
+// the validation error. Enter on invalid input, including an empty answer to
+// a required prompt, shows the error and keeps editing. When stdin is not a
+// terminal the input is validated on submit as usual.
+func (p *Prompt) LiveValidate(enabled bool) *Prompt {
+	p.liveValidate = enabled
+	return p
+}
+
+// AsyncValidator sets a long-running validation function, e.g. one that asks
+// a server whether a username is available. It runs after Validator while a
+// spinner is shown, and a failure re-prompts like any validation error.
+// Cancelling the context passed to RunContext aborts the validation.
+func (p *Prompt) AsyncValidator(validator func(ctx context.Context, input string) error) *Prompt {
+	p.asyncValidator = validator
+	return p
+}
+
+// Normalize sets a function that parses and canonicalizes the input or
+// rejects it: the returned string becomes the value, and an error re-prompts
+// like a validation error. For example, it can turn "1.5k" into "1500" and
+// reject "lots". It runs after Transformer and before Validator, and covers
+// what otherwise takes a transformer and validator parsing the input twice.
+func (p *Prompt) Normalize(normalize func(input string) (string, error)) *Prompt {
+	p.normalizer = normalize
+	return p
+}
+
+// Transformer sets a transformation function applied to the input. It
+// cannot reject input; see Normalize.
 func (p *Prompt) Transformer(transformer func(string) string) *Prompt {
 	p.transformer = transformer
 	return p
@@ -70,6 +174,14 @@ func (p *Prompt) Prefix(prefix string) *Prompt {
 	return p
 }
 
+// Symbols sets the glyphs used by this prompt and resets the prefix to the
+// set's question glyph.
+func (p *Prompt) Symbols(set style.SymbolSet) *Prompt {
+	p.symbols = set
+	p.prefix = set.Question + " "
+	return p
+}
+
 // Style sets the prompt color.
 func (p *Prompt) Style(color *style.Color) *Prompt {
 	p.style = color
@@ -84,192 +196,633 @@ func (p *Prompt) ErrorStyle(color *style.Color) *Prompt {
 
 // Run executes the prompt and returns the user input.
 func (p *Prompt) Run() (string, error) {
-	reader := bufio.NewReader(os.Stdin)
-	
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run but returns the context's error once ctx is
+// cancelled. Cancellation is noticed before each read and aborts a running
+// AsyncValidator; a pending line read is not interrupted.
+func (p *Prompt) RunContext(ctx context.Context) (string, error) {
+	reader := core.Stdin()
+
 	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
 		// Display the prompt
 		p.displayPrompt()
-		
+
 		// Read input
 		var input string
 		var err error
-		
-		if p.hidden {
-			// TODO: Implement hidden input (password)
-			// For now, use regular input
-			input, err = reader.ReadString('\n')
-		} else {
-			input, err = reader.ReadString('\n')
+
+		switch {
+		case p.liveValidate || p.hidden && p.indicator != IndicatorNone:
+			input, err = p.readLive(reader)
+		case p.hidden:
+			input, err = readHidden(reader)
+		default:
+			input, err = readLine(reader)
 		}
-		
+
 		if err != nil {
 			return "", err
 		}
-		
-		// Trim newline
-		input = strings.TrimSpace(input)
-		
-		// Use default if empty
-		if input == "" && p.defaultValue != "" {
-			input = p.defaultValue
-		}
-		
-		// Check required
-		if p.required && input == "" {
-			p.errorStyle.Println("✗ This field is required")
+
+		// Apply defaults, trimming, transformer and validation
+		input, err = p.process(input)
+		if err != nil {
+			fmt.Fprintln(out(), p.errorStyle.Sprint(p.symbols.Error+" "+err.Error()))
 			continue
 		}
-		
-		// Apply transformer
-		if p.transformer != nil {
-			input = p.transformer(input)
+
+		if p.asyncValidator != nil {
+			err := p.validateAsync(ctx, input)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return "", ctxErr
+			}
+			if err != nil {
+				fmt.Fprintln(out(), p.errorStyle.Sprint(p.symbols.Error+" "+err.Error()))
+				continue
+			}
+		}
+
+		return input, nil
+	}
+}
+
+// process turns raw input into the prompt's value: it trims the input, falls
+// back to the default, checks required, transforms, normalizes and validates.
+func (p *Prompt) process(input string) (string, error) {
+	// Trim newline, and surrounding whitespace unless disabled
+	if p.trim {
+		input = strings.TrimSpace(input)
+	} else {
+		input = strings.TrimRight(input, "\r\n")
+	}
+
+	// Use default if empty
+	if input == "" && p.defaultValue != "" {
+		input = p.defaultValue
+	}
+
+	// Check required
+	if p.required && input == "" {
+		return "", errRequired
+	}
+
+	// Apply transformer
+	if p.transformer != nil {
+		input = p.transformer(input)
+	}
+
+	// Normalize, which may reject the input
+	if p.normalizer != nil {
+		normalized, err := p.normalizer(input)
+		if err != nil {
+			return "", err
+		}
+		input = normalized
+	}
+
+	// Validate
+	if p.validator != nil {
+		if err := p.validator(input); err != nil {
+			return "", err
 		}
-		
-		// Validate
+	}
+
+	return input, nil
+}
+
+// validateAsync runs the async validator under a spinner. It returns as soon
+// as ctx is cancelled, even if the validator ignores the context.
+func (p *Prompt) validateAsync(ctx context.Context, input string) error {
+	spinner := ux.NewSpinner(ux.SpinnerDots).Color(p.style)
+	spinner.StartAfter(asyncSpinnerDelay, "Checking...")
+	defer spinner.Stop()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- p.asyncValidator(ctx, input)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readLive reads a line in raw mode, redrawing the input, any password
+// indicator and, with LiveValidate, a validation hint below it on every
+// keystroke. It falls back to a plain read when stdin is not a terminal.
+func (p *Prompt) readLive(reader *bufio.Reader) (string, error) {
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		if p.hidden {
+			return readHidden(reader)
+		}
+		return readLine(reader)
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return "", err
+	}
+	defer guard.Release()
+
+	return p.editLive(core.NewKeyReader(reader))
+}
+
+// editLive runs the raw-mode line editor of readLive on keys. With
+// LiveValidate, Enter on invalid input keeps editing and shows why the
+// input was rejected, even when it is empty.
+func (p *Prompt) editLive(keys *core.KeyReader) (string, error) {
+	prompt := p.promptText()
+	var buf []rune
+	rejected := false
+	for {
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			fmt.Fprint(out(), "\r\n\033[2K")
+			return "", ErrAborted
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch key.Type {
+		case core.KeyEnter:
+			if _, err := p.process(string(buf)); err == nil || !p.liveValidate {
+				// Move below the input and clear the hint
+				fmt.Fprint(out(), "\r\n\033[2K")
+				return string(buf), nil
+			}
+			rejected = true
+		case core.KeyCtrlC:
+			fmt.Fprint(out(), "\r\n\033[2K")
+			return "", ErrCancelled
+		case core.KeyCtrlD:
+			if len(buf) == 0 {
+				fmt.Fprint(out(), "\r\n\033[2K")
+				return "", ErrAborted
+			}
+		case core.KeyBackspace:
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+			}
+		case core.KeyRune:
+			buf = append(buf, key.Rune)
+		}
+
+		p.drawLive(prompt, buf, rejected)
+	}
+}
+
+// drawLive redraws the prompt line with the input so far and the validation
+// hint on the line below, leaving the cursor after the input. The hint is
+// shown once there is input, or once Enter was rejected.
+func (p *Prompt) drawLive(prompt string, buf []rune, rejected bool) {
+	text, indicator := string(buf), ""
+	if p.hidden {
+		text = strings.Repeat("*", len(buf))
+		indicator = passwordIndicator(p.indicator, buf)
+	}
+	line := prompt + text
+
+	hint := ""
+	if p.liveValidate && (len(buf) > 0 || rejected) {
+		if _, err := p.process(string(buf)); err != nil {
+			hint = p.errorStyle.Sprint(p.symbols.Error + " " + err.Error())
+		} else {
+			hint = style.Success.Sprint(p.symbols.Success)
+		}
+	}
+
+	fmt.Fprintf(out(), "\r\033[2K%s%s\r\n\033[2K%s\033[1A\r", line, indicator, hint)
+	if width := core.MeasureText(line); width > 0 {
+		fmt.Fprintf(out(), "\033[%dC", width)
+	}
+}
+
+// RunInt runs the prompt until the input parses as an integer. The validator
+// and transformer are applied first.
+func (p *Prompt) RunInt() (int, error) {
+	input, err := p.runParsed(func(input string) error {
+		_, err := parseInt(input)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return parseInt(input)
+}
+
+// RunFloat runs the prompt until the input parses as a number.
+func (p *Prompt) RunFloat() (float64, error) {
+	input, err := p.runParsed(func(input string) error {
+		_, err := parseFloat(input)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return parseFloat(input)
+}
+
+// RunBool runs the prompt until the input is a yes/no answer such as "y",
+// "no", "true" or "0".
+func (p *Prompt) RunBool() (bool, error) {
+	input, err := p.runParsed(func(input string) error {
+		_, err := parseBool(input)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return parseBool(input)
+}
+
+// runParsed runs a copy of the prompt whose validator also requires parse to
+// succeed, so parse failures re-prompt like any other validation error.
+func (p *Prompt) runParsed(parse func(string) error) (string, error) {
+	prompt := *p
+	prompt.validator = func(input string) error {
 		if p.validator != nil {
 			if err := p.validator(input); err != nil {
-				p.errorStyle.Printf("✗ %s\n", err.Error())
-				continue
+				return err
 			}
 		}
-		
-		return input, nil
+		return parse(input)
+	}
+	return prompt.Run()
+}
+
+func parseInt(input string) (int, error) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, fmt.Errorf("please enter a whole number")
+	}
+	return value, nil
+}
+
+func parseFloat(input string) (float64, error) {
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, fmt.Errorf("please enter a number")
+	}
+	return value, nil
+}
+
+func parseBool(input string) (bool, error) {
+	switch strings.ToLower(input) {
+	case "y", "yes", "true", "1":
+		return true, nil
+	case "n", "no", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("please answer yes or no")
+}
+
+// readHidden reads a line without echoing it. On a terminal the input is read
+// in raw mode under a core.TerminalGuard; otherwise it falls back to a plain read.
+func readHidden(reader *bufio.Reader) (string, error) {
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		return readLine(reader)
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return "", err
+	}
+	defer fmt.Fprintln(out())
+	defer guard.Release()
+
+	keys := core.NewKeyReader(reader)
+	var buf []rune
+	for {
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			return "", ErrAborted
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch key.Type {
+		case core.KeyEnter:
+			return string(buf), nil
+		case core.KeyCtrlC:
+			return "", ErrCancelled
+		case core.KeyCtrlD:
+			if len(buf) == 0 {
+				return "", ErrAborted
+			}
+		case core.KeyBackspace:
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+			}
+		case core.KeyRune:
+			buf = append(buf, key.Rune)
+		}
+	}
+}
+
+// readLine reads a line, mapping EOF without any input to ErrAborted.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			return "", ErrAborted
+		}
+		return line, nil
+	}
+	return line, err
+}
+
+// readValid shows prompt and reads lines from stdin until parse accepts one,
+// printing each parse error and asking again. It fails only when reading
+// does, e.g. with ErrAborted at the end of the input.
+func readValid[T any](prompt string, parse func(input string) (T, error)) (T, error) {
+	reader := core.Stdin()
+	for {
+		fmt.Fprint(out(), prompt)
+		input, err := readLine(reader)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		value, err := parse(input)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Fprintln(out(), style.Error.Sprint(symbols.Error+" "+err.Error()))
 	}
 }
 
 func (p *Prompt) displayPrompt() {
+	fmt.Fprint(out(), p.promptText())
+}
+
+// promptText returns the prompt line shown before the input.
+func (p *Prompt) promptText() string {
 	prompt := p.style.Sprint(p.prefix + p.message)
-	
+
 	if p.defaultValue != "" {
 		prompt += style.Muted.Sprintf(" (%s)", p.defaultValue)
 	}
-	
+
 	if p.required {
 		prompt += style.Error.Sprint(" *")
 	}
-	
+
 	prompt += ": "
-	fmt.Print(prompt)
+	return prompt
 }
 
-// Confirm creates a yes/no confirmation prompt.
+// Confirm creates a yes/no confirmation prompt. An empty answer picks the
+// default; anything but a yes/no answer such as "y" or "no" shows an error
+// and asks again.
 func Confirm(message string, defaultValue ...bool) (bool, error) {
 	defaultVal := false
 	if len(defaultValue) > 0 {
 		defaultVal = defaultValue[0]
 	}
-	
-	prompt := style.Primary.Sprint("? " + message)
-	
+
+	prompt := style.Primary.Sprint(symbols.Question + " " + message)
+
 	if defaultVal {
 		prompt += style.Muted.Sprint(" (Y/n)")
 	} else {
 		prompt += style.Muted.Sprint(" (y/N)")
 	}
-	
+
 	prompt += ": "
-	fmt.Print(prompt)
-	
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return false, err
-	}
-	
-	input = strings.TrimSpace(strings.ToLower(input))
-	
-	if input == "" {
-		return defaultVal, nil
-	}
-	
-	return input == "y" || input == "yes", nil
+
+	return readValid(prompt, func(input string) (bool, error) {
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return defaultVal, nil
+		}
+		return parseBool(input)
+	})
 }
 
-// Select creates a selection prompt from a list of options.
+// Select creates a selection prompt from a list of options. An invalid
+// choice shows an error and asks again, so an error is only returned when
+// reading fails, e.g. ErrAborted at the end of the input.
 func Select(message string, options []string) (int, string, error) {
+	return SelectWithDesc(message, options, nil)
+}
+
+// SelectWithDesc is like Select but shows a dimmed description next to each
+// option. Descriptions are aligned in a column; missing ones are left blank.
+func SelectWithDesc(message string, options, descriptions []string) (int, string, error) {
 	if len(options) == 0 {
 		return -1, "", fmt.Errorf("no options provided")
 	}
-	
+
 	// Display options
-	fmt.Println(style.Primary.Sprint("? " + message))
-	for i, option := range options {
-		fmt.Printf("  %d) %s\n", i+1, option)
-	}
-	
+	fmt.Fprintln(out(), style.Primary.Sprint(symbols.Question+" "+message))
+	printOptions(options, descriptions)
+
 	// Get selection
-	fmt.Print(style.Primary.Sprint("Enter choice (1-" + strconv.Itoa(len(options)) + "): "))
-	
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	prompt := style.Primary.Sprint("Enter choice (1-" + strconv.Itoa(len(options)) + " or name): ")
+	index, err := readValid(prompt, func(input string) (int, error) {
+		return resolveChoice(options, input)
+	})
 	if err != nil {
 		return -1, "", err
 	}
-	
-	choice, err := strconv.Atoi(strings.TrimSpace(input))
+
+	return index, options[index], nil
+}
+
+// resolveChoice returns the index of the option chosen by input, either a
+// 1-based number or the option text matched with findOption.
+func resolveChoice(options []string, input string) (int, error) {
+	input = strings.TrimSpace(input)
+	choice, err := strconv.Atoi(input)
 	if err != nil {
-		return -1, "", fmt.Errorf("invalid choice: %s", input)
+		if index := findOption(options, input); index >= 0 {
+			return index, nil
+		}
+		return -1, fmt.Errorf("invalid choice: %s", input)
 	}
-	
+
 	if choice < 1 || choice > len(options) {
-		return -1, "", fmt.Errorf("choice must be between 1 and %d", len(options))
+		return -1, fmt.Errorf("choice must be between 1 and %d", len(options))
 	}
-	
-	return choice - 1, options[choice-1], nil
+	return choice - 1, nil
 }
 
-// MultiSelect creates a multi-selection prompt.
-func MultiSelect(message string, options []string) ([]int, []string, error) {
+// resolveChoices returns the indices of the options chosen by a
+// comma-separated list of numbers, names and ranges such as "1-3,5". Empty
+// entries, e.g. from a trailing comma, are ignored and repeated choices are
+// kept once, in the order first given.
+func resolveChoices(options []string, input string) ([]int, error) {
+	var indices []int
+	seen := make(map[int]bool)
+	add := func(index int) {
+		if !seen[index] {
+			seen[index] = true
+			indices = append(indices, index)
+		}
+	}
+
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		index, err := resolveChoice(options, part)
+		if err == nil {
+			add(index)
+			continue
+		}
+
+		// A number or name that resolveChoice rejected keeps its error
+		low, high, isRange := core.ParseRange(part)
+		if !isRange || !strings.Contains(part, "-") {
+			return nil, err
+		}
+		if low < 1 || high > len(options) || low > high {
+			return nil, fmt.Errorf("invalid range %s: choices must be between 1 and %d", part, len(options))
+		}
+		for choice := low; choice <= high; choice++ {
+			add(choice - 1)
+		}
+	}
+	return indices, nil
+}
+
+// Option is a selectable choice that carries a typed value.
+type Option[T any] struct {
+	// Label is the text shown to the user.
+	Label string
+	// Description is an optional dimmed hint shown next to the label.
+	Description string
+	// Value is returned when the option is chosen.
+	Value T
+}
+
+// SelectValues is like Select but returns the value associated with the
+// chosen option, so callers don't have to map labels back to their own types.
+func SelectValues[T any](message string, options []Option[T]) (T, error) {
+	var zero T
+
+	labels := make([]string, len(options))
+	descriptions := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = option.Label
+		descriptions[i] = option.Description
+	}
+
+	index, _, err := SelectWithDesc(message, labels, descriptions)
+	if err != nil {
+		return zero, err
+	}
+	return options[index].Value, nil
+}
+
+// printOptions prints numbered options, aligning descriptions in a column.
+func printOptions(options, descriptions []string) {
+	labels := make([]string, len(options))
+	maxWidth := 0
+	for i, option := range options {
+		labels[i] = fmt.Sprintf("%d) %s", i+1, option)
+		if width := core.StringWidth(labels[i]); width > maxWidth {
+			maxWidth = width
+		}
+	}
+
+	for i, label := range labels {
+		line := "  " + label
+		if i < len(descriptions) && descriptions[i] != "" {
+			padding := maxWidth - core.StringWidth(label)
+			line += strings.Repeat(" ", padding+2) + style.Muted.Sprint(descriptions[i])
+		}
+		fmt.Fprintln(out(), line)
+	}
+}
+
+// MultiSelect creates a multi-selection prompt. Like Select, it asks again
+// after an invalid choice. Options listed in initial, e.g. the current
+// values of a setting being edited, are marked as selected and kept when
+// the answer is empty; "none" clears the selection.
+func MultiSelect(message string, options []string, initial ...int) ([]int, []string, error) {
 	if len(options) == 0 {
 		return nil, nil, fmt.Errorf("no options provided")
 	}
-	
+	current := initialChoices(options, initial)
+	chosen := make(map[int]bool)
+	for _, index := range current {
+		chosen[index] = true
+	}
+
 	// Display options
-	fmt.Println(style.Primary.Sprint("? " + message + " (comma-separated numbers)"))
+	fmt.Fprintln(out(), style.Primary.Sprint(symbols.Question+" "+message+" (comma-separated numbers, ranges like 1-3, or names)"))
 	for i, option := range options {
-		fmt.Printf("  %d) %s\n", i+1, option)
+		line := fmt.Sprintf("  %d) %s", i+1, option)
+		if chosen[i] {
+			line += " " + style.Success.Sprint(symbols.CheckMark)
+		}
+		fmt.Fprintln(out(), line)
 	}
-	
+
 	// Get selections
-	fmt.Print(style.Primary.Sprint("Enter choices: "))
-	
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	prompt := "Enter choices: "
+	if len(current) > 0 {
+		prompt = "Enter choices (empty keeps the marked ones, none clears them): "
+	}
+	indices, err := readValid(style.Primary.Sprint(prompt), func(input string) ([]int, error) {
+		input = strings.TrimSpace(input)
+		switch {
+		case input == "":
+			return current, nil
+		case strings.EqualFold(input, "none") && findOption(options, input) < 0:
+			return []int{}, nil
+		}
+		return resolveChoices(options, input)
+	})
 	if err != nil {
 		return nil, nil, err
 	}
-	
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return []int{}, []string{}, nil
+
+	selected := make([]string, len(indices))
+	for i, index := range indices {
+		selected[i] = options[index]
 	}
-	
-	parts := strings.Split(input, ",")
-	var indices []int
-	var selected []string
-	
-	for _, part := range parts {
-		choice, err := strconv.Atoi(strings.TrimSpace(part))
-		if err != nil {
-			return nil, nil, fmt.Errorf("invalid choice: %s", part)
-		}
-		
-		if choice < 1 || choice > len(options) {
-			return nil, nil, fmt.Errorf("choice must be between 1 and %d", len(options))
+
+	return indices, selected, nil
+}
+
+// initialChoices returns the valid indices in initial, in ascending order
+// and without repeats.
+func initialChoices(options []string, initial []int) []int {
+	indices := []int{}
+	for i := range options {
+		for _, index := range initial {
+			if index == i {
+				indices = append(indices, i)
+				break
+			}
 		}
-		
-		indices = append(indices, choice-1)
-		selected = append(selected, options[choice-1])
 	}
-	
-	return indices, selected, nil
+	return indices
 }
 
-// Password creates a hidden password input prompt.
+// Password creates a hidden password input prompt. The input is not trimmed,
+// since leading and trailing spaces are valid password characters.
 func Password(message string) (string, error) {
 	prompt := NewPrompt(message).
 		Hidden(true).
+		Trim(false).
 		Required(true)
-	
+
 	return prompt.Run()
-}
\ No newline at end of file
+}
@@ -0,0 +1,330 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+)
+
+func TestReadLineEOF(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("first\nlast"))
+
+	line, err := readLine(reader)
+	if line != "first\n" || err != nil {
+		t.Errorf("Expected complete line, got %q, %v", line, err)
+	}
+
+	line, err = readLine(reader)
+	if line != "last" || err != nil {
+		t.Errorf("Expected final line without newline to be accepted, got %q, %v", line, err)
+	}
+
+	if _, err = readLine(reader); err != ErrAborted {
+		t.Errorf("Expected ErrAborted at EOF, got %v", err)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for input, expected := range map[string]bool{"y": true, "YES": true, "1": true, "n": false, "False": false} {
+		if got, err := parseBool(input); got != expected || err != nil {
+			t.Errorf("parseBool(%q) = %v, %v; want %v", input, got, err, expected)
+		}
+	}
+	if _, err := parseBool("maybe"); err == nil {
+		t.Error("Expected error for unrecognized answer")
+	}
+}
+
+func TestParseConfirmBatch(t *testing.T) {
+	for input, expected := range map[string]ConfirmResult{"y": ConfirmYes, "No": ConfirmNo, " a ": ConfirmAll, "quit": ConfirmQuit} {
+		if got, ok := parseConfirmBatch(input); got != expected || !ok {
+			t.Errorf("parseConfirmBatch(%q) = %v, %v; want %v", input, got, ok, expected)
+		}
+	}
+	if _, ok := parseConfirmBatch("?"); ok {
+		t.Error("Expected unrecognized answer to be rejected")
+	}
+}
+
+func TestResolveChoice(t *testing.T) {
+	options := []string{"Developer", "Designer", "Product Manager"}
+	for input, expected := range map[string]int{"2": 1, " developer ": 0, "product   manager": 2} {
+		if got, err := resolveChoice(options, input); got != expected || err != nil {
+			t.Errorf("resolveChoice(%q) = %d, %v; want %d", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"0", "4", "tester"} {
+		if _, err := resolveChoice(options, input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestResolveChoices(t *testing.T) {
+	options := []string{"lint", "test", "build", "deploy", "dry-run"}
+	tests := map[string][]int{
+		"1, 2,":        {0, 1},
+		"1-3,5":        {0, 1, 2, 4},
+		"2, 1-3, test": {1, 0, 2},
+		" 4 - 5 ":      {3, 4},
+		"dry-run, 1":   {4, 0},
+		",,":           nil,
+	}
+	for input, expected := range tests {
+		got, err := resolveChoices(options, input)
+		if err != nil || fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Errorf("resolveChoices(%q) = %v, %v; want %v", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"1,x", "0-2", "3-1", "4-9", "1-"} {
+		if _, err := resolveChoices(options, input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestPromptNormalize(t *testing.T) {
+	prompt := NewPrompt("Port").
+		Transformer(strings.ToLower).
+		Normalize(func(input string) (string, error) {
+			if input == "http" {
+				return "80", nil
+			}
+			if _, err := parseInt(input); err != nil {
+				return "", err
+			}
+			return input, nil
+		}).
+		Validator(func(input string) error {
+			if input == "0" {
+				return errors.New("port 0 is reserved")
+			}
+			return nil
+		})
+
+	for input, expected := range map[string]string{" HTTP ": "80", "8080": "8080"} {
+		if got, err := prompt.process(input); got != expected || err != nil {
+			t.Errorf("process(%q) = %q, %v; want %q", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"lots", "0"} {
+		if _, err := prompt.process(input); err == nil {
+			t.Errorf("Expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestPasswordIndicator(t *testing.T) {
+	strengths := map[string]int{
+		"":             0,
+		"пароль":       0,
+		"Pass1!":       1,
+		"correcthorse": 2,
+		"Correct1":     3,
+		"Tr0ub4dor&3x": 4,
+	}
+	for password, expected := range strengths {
+		if got := passwordStrength([]rune(password)); got != expected {
+			t.Errorf("passwordStrength(%q) = %d, want %d", password, got, expected)
+		}
+	}
+
+	// Multi-byte characters count once each
+	if got := core.StripANSI(passwordIndicator(IndicatorLength, []rune("日本語🔑"))); got != " (4)" {
+		t.Errorf("length indicator = %q, want \" (4)\"", got)
+	}
+	if got := core.StripANSI(passwordIndicator(IndicatorStrength, []rune("correcthorse"))); got != " ██░░ fair" {
+		t.Errorf("strength indicator = %q", got)
+	}
+	if got := passwordIndicator(IndicatorNone, []rune("secret")); got != "" {
+		t.Errorf("no indicator expected, got %q", got)
+	}
+}
+
+// withStdin runs run with input as stdin.
+func withStdin(t *testing.T, input string, run func()) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.WriteString(input)
+	w.Close()
+	run()
+}
+
+func TestSelectRepromptsOnInvalidInput(t *testing.T) {
+	options := []string{"small", "medium", "large"}
+	withStdin(t, "7\nhuge\n2\n", func() {
+		if index, option, err := Select("Size", options); index != 1 || option != "medium" || err != nil {
+			t.Errorf("Select = %d, %q, %v; want 1, medium", index, option, err)
+		}
+	})
+	withStdin(t, "1,x\n1-2\n", func() {
+		if indices, _, err := MultiSelect("Sizes", options); fmt.Sprint(indices) != "[0 1]" || err != nil {
+			t.Errorf("MultiSelect = %v, %v; want [0 1]", indices, err)
+		}
+	})
+	withStdin(t, "maybe\nyes\n", func() {
+		if ok, err := Confirm("Continue?"); !ok || err != nil {
+			t.Errorf("Confirm = %v, %v; want true", ok, err)
+		}
+	})
+	withStdin(t, "nope\n", func() {
+		if _, _, err := Select("Size", options); !errors.Is(err, ErrAborted) {
+			t.Errorf("expected ErrAborted once the input ends, got %v", err)
+		}
+	})
+}
+
+func TestMultiSelectInitial(t *testing.T) {
+	options := []string{"red", "green", "blue"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"\n", "[green blue]"},
+		{"1\n", "[red]"},
+		{"none\n", "[]"},
+	}
+
+	for _, tt := range tests {
+		withStdin(t, tt.input, func() {
+			_, selected, err := MultiSelect("Colors", options, 2, 1, 7)
+			if fmt.Sprint(selected) != tt.want || err != nil {
+				t.Errorf("MultiSelect with %q = %v, %v; want %s", tt.input, selected, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmWithPreview(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"\n", false},
+		{"y\n", true},
+	}
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	for _, tt := range tests {
+		buf.Reset()
+		var ok bool
+		var err error
+		withStdin(t, tt.input, func() {
+			ok, err = ConfirmWithPreview("Delete these files?", core.Text("a.txt\nb.txt\n"), nil)
+		})
+
+		if ok != tt.want || err != nil {
+			t.Errorf("answer %q: got %v, %v; want %v", tt.input, ok, err, tt.want)
+		}
+		if got := core.StripANSI(buf.String()); !strings.HasPrefix(got, "a.txt\nb.txt\n? Delete these files? (y/N)") {
+			t.Errorf("expected the preview before the question, got %q", got)
+		}
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	withStdin(t, "2\n", func() {
+		if index, _, err := Select("Size", []string{"small", "large"}); index != 1 || err != nil {
+			t.Errorf("Select = %d, %v; want 1", index, err)
+		}
+	})
+	if got := core.StripANSI(buf.String()); !strings.Contains(got, "? Size") || !strings.Contains(got, "large") {
+		t.Errorf("expected the prompt in the output, got %q", got)
+	}
+
+	SetOutput(nil)
+	if out() != os.Stdout {
+		t.Error("SetOutput(nil) should restore os.Stdout")
+	}
+}
+
+func TestSetOptionMatcher(t *testing.T) {
+	defer SetOptionMatcher(core.FoldMatch)
+	options := []string{"Small", "Large"}
+
+	if index := findOption(options, " large "); index != 1 {
+		t.Errorf("default matcher: index = %d, want 1", index)
+	}
+	SetOptionMatcher(nil)
+	if index := findOption(options, "large"); index != -1 {
+		t.Errorf("nil matcher should require an exact match, got %d", index)
+	}
+
+	// Switching the matcher while prompts resolve answers must not race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetOptionMatcher(core.FoldMatch)
+			SetOptionMatcher(nil)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		findOption(options, "Large")
+	}
+	<-done
+}
+
+func TestPromptLiveValidate(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	p := NewPrompt("Port").Required(true).LiveValidate(true).Validator(func(s string) error {
+		if _, err := strconv.Atoi(s); err != nil {
+			return errors.New("not a number")
+		}
+		return nil
+	})
+	keys := func(input string) *core.KeyReader {
+		return core.NewKeyReader(bufio.NewReader(strings.NewReader(input)))
+	}
+
+	// Enter on empty and invalid input is rejected with the reason
+	value, err := p.editLive(keys("\rx\r\x7f80\r"))
+	if value != "80" || err != nil {
+		t.Fatalf("editLive = %q, %v; want 80", value, err)
+	}
+	output := core.StripANSI(buf.String())
+	for _, want := range []string{"this field is required", "not a number", "✓"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output %q does not contain %q", output, want)
+		}
+	}
+
+	// Without LiveValidate, Enter submits as typed and Run validates
+	buf.Reset()
+	if value, err := NewPrompt("Name").Required(true).editLive(keys("\r")); value != "" || err != nil {
+		t.Errorf("editLive = %q, %v; want an empty submission", value, err)
+	}
+	if strings.Contains(buf.String(), "required") {
+		t.Errorf("expected no hint without LiveValidate, got %q", buf.String())
+	}
+
+	if _, err := p.editLive(keys("\x03")); err != ErrCancelled {
+		t.Errorf("Ctrl-C: err = %v, want ErrCancelled", err)
+	}
+	if _, err := p.editLive(keys("12")); err != ErrAborted {
+		t.Errorf("EOF: err = %v, want ErrAborted", err)
+	}
+}
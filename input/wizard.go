@@ -0,0 +1,176 @@
+// Package input provides multi-page form wizards.
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bagaking/cmdux/style"
+	"github.com/bagaking/cmdux/ui"
+)
+
+// Wizard runs a sequence of forms as pages, letting the user go back to a
+// previous page, and merges all answers into a single results map.
+type Wizard struct {
+	title   string
+	pages   []*Form
+	summary bool
+	theme   *style.Theme
+	results map[string]interface{}
+}
+
+// NewWizard creates a new wizard. By default a summary of all answers is shown
+// for confirmation after the last page.
+func NewWizard() *Wizard {
+	return &Wizard{
+		summary: true,
+		results: make(map[string]interface{}),
+	}
+}
+
+// Title sets the wizard title shown above every page.
+func (w *Wizard) Title(title string) *Wizard {
+	w.title = title
+	return w
+}
+
+// AddPage appends a form as the next page.
+func (w *Wizard) AddPage(page *Form) *Wizard {
+	w.pages = append(w.pages, page)
+	return w
+}
+
+// Theme sets the theme of the page headers and the summary. A nil theme,
+// the default, uses style.DefaultTheme.
+func (w *Wizard) Theme(theme *style.Theme) *Wizard {
+	w.theme = theme
+	return w
+}
+
+// Summary enables or disables the final confirmation page.
+func (w *Wizard) Summary(enabled bool) *Wizard {
+	w.summary = enabled
+	return w
+}
+
+// Run runs the pages in order and returns the merged results. After each page
+// the user can continue or go back; declining the summary returns to the last
+// page. Like Form.Run it returns the answers collected so far on error. Every
+// run starts without answers.
+func (w *Wizard) Run() (map[string]interface{}, error) {
+	w.results = make(map[string]interface{})
+	if len(w.pages) == 0 {
+		return w.results, nil
+	}
+
+	theme := w.theme
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+
+	page := 0
+	for page < len(w.pages) {
+		header := fmt.Sprintf("Page %d of %d", page+1, len(w.pages))
+		if w.title != "" {
+			header = w.title + " · " + header
+		}
+		fmt.Fprintln(out(), style.ColorOr(theme.Muted, style.Muted).Sprint(header))
+
+		results, err := w.pages[page].Run()
+		for name, value := range results {
+			w.results[name] = value
+		}
+		if err != nil {
+			return w.results, err
+		}
+
+		back := false
+		if page > 0 {
+			if back, err = w.askBack(); err != nil {
+				return w.results, err
+			}
+		}
+		if back {
+			page--
+			continue
+		}
+
+		if page == len(w.pages)-1 && w.summary {
+			fmt.Fprintln(out())
+			fmt.Fprintln(out(), w.renderSummary(theme))
+			confirmed, err := Confirm("Submit these answers?", true)
+			if err != nil {
+				return w.results, err
+			}
+			if !confirmed {
+				continue // Redo the last page
+			}
+		}
+		page++
+	}
+
+	return w.results, nil
+}
+
+// askBack asks whether to continue or return to the previous page.
+func (w *Wizard) askBack() (bool, error) {
+	answer, err := NewPrompt(`Press Enter to continue, or "b" to go back`).
+		Validator(func(input string) error {
+			switch strings.ToLower(input) {
+			case "", "b", "back":
+				return nil
+			}
+			return fmt.Errorf(`enter nothing to continue or "b" to go back`)
+		}).
+		Run()
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintln(out())
+	return answer != "", nil
+}
+
+// renderSummary lists every answer in page and field order under theme.
+// Passwords are masked.
+func (w *Wizard) renderSummary(theme *style.Theme) string {
+	summary := ui.NewKeyValue()
+	for _, page := range w.pages {
+		for _, field := range page.fields {
+			value, ok := w.results[field.Name]
+			if !ok {
+				continue
+			}
+
+			label := field.Label
+			if label == "" {
+				label = field.Name
+			}
+			summary.Add(label, formatAnswer(field, value))
+		}
+	}
+	return summary.Render(theme)
+}
+
+// passwordMask stands in for passwords in the summary. It has a fixed length
+// so the summary does not reveal how long a password is.
+const passwordMask = "••••••"
+
+// formatAnswer returns a result as shown in the summary.
+func formatAnswer(field FormField, value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case string:
+		if field.Type == FieldTypePassword {
+			return passwordMask
+		}
+		return v
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	default:
+		return fmt.Sprint(v)
+	}
+}
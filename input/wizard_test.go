@@ -0,0 +1,85 @@
+package input
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func newTestWizard() *Wizard {
+	return NewWizard().Title("Setup").
+		AddPage(NewForm("").TextField("name", "Name", true)).
+		AddPage(NewForm("").TextField("color", "Color", false).PasswordField("token", "Token", false))
+}
+
+func TestWizardBackNavigation(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	// Page 1, page 2, go back, page 1 again, page 2 again, continue, submit
+	input := "Ada\nred\nabc\nb\nGrace\nblue\nxyz\n\ny\n"
+	var results map[string]interface{}
+	var err error
+	withStdin(t, input, func() {
+		results, err = newTestWizard().Run()
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if results["name"] != "Grace" || results["color"] != "blue" || results["token"] != "xyz" {
+		t.Errorf("expected the answers from the second pass, got %v", results)
+	}
+
+	output := core.StripANSI(buf.String())
+	if got := strings.Count(output, "Setup · Page 1 of 2"); got != 2 {
+		t.Errorf("expected page 1 twice, got %d times in\n%s", got, output)
+	}
+	for _, want := range []string{"Name:  Grace", "Color: blue", "Token: ••••••"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("summary does not contain %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestWizardSummaryTheme(t *testing.T) {
+	w := newTestWizard()
+	w.results = map[string]interface{}{"name": "Ada", "token": "s3"}
+
+	theme := style.DefaultTheme().WithColors(false)
+	if got := w.renderSummary(theme); got != "Name:  Ada\nToken: ••••••" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestWizardDeclinedSummaryRedoesLastPage(t *testing.T) {
+	SetOutput(&bytes.Buffer{})
+	defer SetOutput(nil)
+
+	var results map[string]interface{}
+	var err error
+	withStdin(t, "Ada\nn\nAda Lovelace\ny\n", func() {
+		results, err = NewWizard().AddPage(NewForm("").TextField("name", "Name", true)).Run()
+	})
+	if err != nil || results["name"] != "Ada Lovelace" {
+		t.Errorf("Run = %v, %v; want the redone answer", results, err)
+	}
+}
+
+func TestWizardRunStartsFresh(t *testing.T) {
+	SetOutput(&bytes.Buffer{})
+	defer SetOutput(nil)
+
+	w := NewWizard().Summary(false).AddPage(NewForm("").TextField("name", "Name", true))
+	withStdin(t, "Ada\n", func() { w.Run() })
+
+	// A run that fails before any answer must not return the last run's
+	var results map[string]interface{}
+	withStdin(t, "", func() { results, _ = w.Run() })
+	if len(results) != 0 {
+		t.Errorf("expected no answers from an aborted second run, got %v", results)
+	}
+}
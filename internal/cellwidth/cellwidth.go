@@ -0,0 +1,73 @@
+// Package cellwidth measures how many terminal cells text occupies. It is
+// shared by core, which exposes it as core.StringWidth, and style, which
+// cannot import core.
+package cellwidth
+
+import (
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// emojiPresentation is the variation selector that asks for a character to be
+// shown as a (double-width) emoji, as in "❤️" or "🏳️‍🌈".
+const emojiPresentation = '\uFE0F'
+
+// emojiWidth overrides the width of emoji clusters when non-zero. See
+// SetEmoji.
+var emojiWidth int
+
+// String returns the number of cells plain text occupies, measured by
+// grapheme cluster. See core.StringWidth.
+func String(text string) int {
+	width := 0
+	g := uniseg.NewGraphemes(text)
+	for g.Next() {
+		width += Cluster(g.Runes())
+	}
+	return width
+}
+
+// SetEmoji sets the width of emoji clusters, or restores the default of two
+// cells for 0. See core.SetEmojiWidth.
+func SetEmoji(width int) {
+	emojiWidth = width
+}
+
+// Cluster returns the display width of a single grapheme cluster.
+func Cluster(cluster []rune) int {
+	if isEmoji(cluster) {
+		if emojiWidth > 0 {
+			return emojiWidth
+		}
+		return 2
+	}
+	return runewidth.StringWidth(string(cluster))
+}
+
+// isEmoji reports whether a grapheme cluster is displayed as an emoji: a
+// flag, a character with the emoji variation selector, or a pictograph that
+// is wide by default such as "😀" (with any modifiers and ZWJ sequences).
+func isEmoji(cluster []rune) bool {
+	if len(cluster) == 2 && isRegionalIndicator(cluster[0]) && isRegionalIndicator(cluster[1]) {
+		return true
+	}
+	for _, r := range cluster {
+		if r == emojiPresentation {
+			return true
+		}
+	}
+	first := cluster[0]
+	pictograph := first >= 0x2300 && first <= 0x2BFF || first >= 0x1F000 && first <= 0x1FAFF
+	return pictograph && narrowCondition.RuneWidth(first) == 2
+}
+
+// narrowCondition measures runes independently of core.SetAmbiguousWidth, so
+// ambiguous symbols such as "①" are not mistaken for emoji when they are
+// shown wide.
+var narrowCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+
+// isRegionalIndicator reports whether r is one of the letters that pair up
+// into a flag emoji, e.g. "🇯🇵".
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
@@ -126,4 +126,25 @@ var (
 	Italic    = color.New(color.Italic)
 	Underline = color.New(color.Underline)
 	Faint     = color.New(color.Faint)
-)
\ No newline at end of file
+
+	// UI elements
+	Border   = color.New(color.FgHiCyan)
+	Header   = color.New(color.FgHiWhite, color.Bold)
+	Footer   = color.New(color.FgHiBlack)
+	Selected = color.New(color.FgHiMagenta)
+	Disabled = color.New(color.FgHiBlack)
+
+	// Backgrounds, for solid fills
+	PrimaryBg = color.New(color.BgHiCyan)
+	MutedBg   = color.New(color.BgHiBlack)
+)
+
+// ColorOr returns c if it is set, otherwise fallback. Components use it to
+// tolerate partially-built themes such as a &Theme{} literal with only some
+// fields set.
+func ColorOr(c, fallback *Color) *Color {
+	if c != nil {
+		return c
+	}
+	return fallback
+}
\ No newline at end of file
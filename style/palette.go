@@ -0,0 +1,127 @@
+// Package style provides 256-color palette support.
+package style
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// Named entries of the xterm 256-color palette, for use with Color256 and
+// BgColor256. They sit between the 16 basic colors and truecolor, and render
+// on most modern terminals.
+const (
+	Orange256 uint8 = 208
+	Gold256   uint8 = 220
+	Coral256  uint8 = 209
+	Pink256   uint8 = 205
+	Purple256 uint8 = 141
+	Teal256   uint8 = 37
+	Sky256    uint8 = 117
+	Lime256   uint8 = 154
+	Gray256   uint8 = 245
+	Slate256  uint8 = 240
+
+	// Approximations of the Okabe-Ito palette, whose colors stay distinct
+	// under the common forms of color vision deficiency
+	Azure256     uint8 = 32
+	Vermilion256 uint8 = 166
+	Amber256     uint8 = 214
+	Yellow256    uint8 = 227
+	Orchid256    uint8 = 175
+)
+
+var (
+	color256Once      sync.Once
+	color256Supported bool
+)
+
+// Supports256Colors reports whether 256-color output is used. It is detected
+// from TERM (e.g. "xterm-256color") and COLORTERM unless set with
+// SetColor256Support.
+func Supports256Colors() bool {
+	color256Once.Do(func() {
+		term := os.Getenv("TERM")
+		colorterm := os.Getenv("COLORTERM")
+		color256Supported = strings.Contains(term, "256color") || colorterm == "truecolor" || colorterm == "24bit"
+	})
+	return color256Supported
+}
+
+// SetColor256Support overrides the detected 256-color support. It affects
+// colors created afterwards, so call it before building themes.
+func SetColor256Support(enabled bool) {
+	color256Once.Do(func() {})
+	color256Supported = enabled
+}
+
+// Color256 returns a foreground color from the xterm 256-color palette. On
+// terminals without 256-color support the nearest of the 16 basic colors is
+// used instead.
+func Color256(n uint8) *Color {
+	if !Supports256Colors() {
+		return color.New(color.FgBlack + basicAttribute(nearestBasic(n)))
+	}
+	return color.New(38, 5, color.Attribute(n))
+}
+
+// BgColor256 returns a background color from the xterm 256-color palette,
+// downgraded like Color256.
+func BgColor256(n uint8) *Color {
+	if !Supports256Colors() {
+		return color.New(color.BgBlack + basicAttribute(nearestBasic(n)))
+	}
+	return color.New(48, 5, color.Attribute(n))
+}
+
+// basicAttribute returns the offset from FgBlack (or BgBlack) of a basic
+// color index: 0-7 are the normal colors and 8-15 the bright ones.
+func basicAttribute(index int) color.Attribute {
+	if index < 8 {
+		return color.Attribute(index)
+	}
+	return color.FgHiBlack - color.FgBlack + color.Attribute(index-8)
+}
+
+// basicRGB holds the xterm default values of the 16 basic colors.
+var basicRGB = [16][3]int{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+	{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+	{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+// palette256RGB returns the RGB value of an xterm 256-color palette index.
+func palette256RGB(n uint8) [3]int {
+	switch {
+	case n < 16:
+		return basicRGB[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		i := int(n) - 16
+		return [3]int{levels[i/36], levels[(i/6)%6], levels[i%6]}
+	default:
+		gray := 8 + (int(n)-232)*10
+		return [3]int{gray, gray, gray}
+	}
+}
+
+// nearestBasic returns the index of the basic color closest to a 256-color
+// palette entry.
+func nearestBasic(n uint8) int {
+	if n < 16 {
+		return int(n)
+	}
+
+	rgb := palette256RGB(n)
+	best, bestDist := 0, -1
+	for i, c := range basicRGB {
+		dr, dg, db := rgb[0]-c[0], rgb[1]-c[1], rgb[2]-c[2]
+		if dist := dr*dr + dg*dg + db*db; bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
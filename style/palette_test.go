@@ -0,0 +1,55 @@
+package style
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestColor256(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	supported := Supports256Colors()
+	defer func() {
+		color.NoColor = noColor
+		SetColor256Support(supported)
+	}()
+
+	SetColor256Support(true)
+	if got := Color256(Orange256).Sprint("x"); !strings.HasPrefix(got, "\x1b[38;5;208mx") {
+		t.Errorf("256-color foreground: got %q", got)
+	}
+	if got := BgColor256(Teal256).Sprint("x"); !strings.HasPrefix(got, "\x1b[48;5;37mx") {
+		t.Errorf("256-color background: got %q", got)
+	}
+
+	SetColor256Support(false)
+	if !Color256(Orange256).Equals(color.New(color.FgYellow)) {
+		t.Errorf("expected orange to downgrade to yellow, got %q", Color256(Orange256).Sprint("x"))
+	}
+	if !BgColor256(9).Equals(color.New(color.BgHiRed)) {
+		t.Errorf("expected index 9 to map to the bright red background")
+	}
+	if warnings := SunsetTheme().Validate(); len(warnings) != 0 {
+		t.Errorf("downgraded Sunset theme has warnings: %v", warnings)
+	}
+}
+
+func TestNearestBasic(t *testing.T) {
+	tests := map[uint8]int{
+		1:   1,  // basic colors map to themselves
+		12:  12, //
+		196: 9,  // pure red
+		46:  10, // pure green
+		21:  4,  // blue
+		232: 0,  // darkest gray
+		255: 7,  // lightest gray
+		244: 8,  // mid gray
+	}
+	for n, want := range tests {
+		if got := nearestBasic(n); got != want {
+			t.Errorf("nearestBasic(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package style provides multi-colored text segments.
+package style
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/internal/cellwidth"
+)
+
+// Segments builds a line from runs of text in different colors, e.g. to
+// highlight search matches or syntax, and measures it without counting the
+// color codes:
+//
+//	line := style.NewSegments().
+//		Add("error: ", style.Error).
+//		Add("file not found", nil)
+//	fmt.Println(line.String(), line.Width())
+type Segments struct {
+	parts []segment
+}
+
+// segment is a run of text and the color it is drawn in.
+type segment struct {
+	text  string
+	color *Color
+}
+
+// NewSegments creates an empty segment builder.
+func NewSegments() *Segments {
+	return &Segments{}
+}
+
+// Add appends text drawn in color. A nil color leaves the text unstyled. The
+// text must be plain: escape sequences in it are not stripped, so they would
+// be counted by Width. Style text through the color argument instead.
+func (s *Segments) Add(text string, color *Color) *Segments {
+	s.parts = append(s.parts, segment{text: text, color: color})
+	return s
+}
+
+// String returns the styled text.
+func (s *Segments) String() string {
+	var out strings.Builder
+	for _, part := range s.parts {
+		if part.color == nil {
+			out.WriteString(part.text)
+			continue
+		}
+		out.WriteString(part.color.Sprint(part.text))
+	}
+	return out.String()
+}
+
+// Plain returns the text without any styling.
+func (s *Segments) Plain() string {
+	var out strings.Builder
+	for _, part := range s.parts {
+		out.WriteString(part.text)
+	}
+	return out.String()
+}
+
+// Width returns the number of terminal cells the text occupies. It measures
+// Plain, which for plain segment text is what core.MeasureText reports for
+// String.
+func (s *Segments) Width() int {
+	return cellwidth.String(s.Plain())
+}
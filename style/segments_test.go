@@ -0,0 +1,26 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestSegments(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	red := color.New(color.FgRed)
+	line := NewSegments().Add("find ", nil).Add("中文", red).Add(" here", nil)
+
+	if got, want := line.String(), "find "+red.Sprint("中文")+" here"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := line.Plain(); got != "find 中文 here" {
+		t.Errorf("Plain() = %q", got)
+	}
+	if got := line.Width(); got != 14 {
+		t.Errorf("Width() = %d, want 14", got)
+	}
+}
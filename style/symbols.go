@@ -1,6 +1,13 @@
 // Package style provides symbol definitions for drawing UI elements.
 package style
 
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
 // Box drawing characters for modern terminals
 const (
 	BoxTopLeft     = "╭"
@@ -23,6 +30,7 @@ const (
 	Arrow     = "▸"
 	CheckMark = "✓"
 	CrossMark = "✗"
+	Question  = "?"
 	Lightning = "⚡"
 	Gear      = "⚙"
 	Rocket    = "🚀"
@@ -74,6 +82,7 @@ type SymbolSet struct {
 	BoxHorizontal  string
 	BoxVertical    string
 	BoxTee         string
+	BoxTeeRight    string
 	BoxCross       string
 	
 	// UI elements
@@ -83,6 +92,13 @@ type SymbolSet struct {
 	CrossMark  string
 	Selected   string
 	Unselected string
+	Warning    string
+	Info       string
+
+	// Prompt glyphs
+	Question string
+	Error    string
+	Success  string
 }
 
 // DefaultSymbols returns the default Unicode symbol set.
@@ -95,6 +111,7 @@ func DefaultSymbols() SymbolSet {
 		BoxHorizontal:  BoxHorizontal,
 		BoxVertical:    BoxVertical,
 		BoxTee:         BoxTee,
+		BoxTeeRight:    BoxTeeRight,
 		BoxCross:       BoxCross,
 		
 		Bullet:     Bullet,
@@ -103,6 +120,12 @@ func DefaultSymbols() SymbolSet {
 		CrossMark:  CrossMark,
 		Selected:   "▶",
 		Unselected: " ",
+		Warning:    "⚠",
+		Info:       "ℹ",
+
+		Question: Question,
+		Error:    CrossMark,
+		Success:  CheckMark,
 	}
 }
 
@@ -116,6 +139,7 @@ func ASCIISymbols() SymbolSet {
 		BoxHorizontal:  ClassicBoxHorizontal,
 		BoxVertical:    ClassicBoxVertical,
 		BoxTee:         "+",
+		BoxTeeRight:    "+",
 		BoxCross:       "+",
 		
 		Bullet:     ClassicBullet,
@@ -124,5 +148,53 @@ func ASCIISymbols() SymbolSet {
 		CrossMark:  ClassicCrossMark,
 		Selected:   ">",
 		Unselected: " ",
+		Warning:    "!",
+		Info:       "i",
+
+		Question: Question,
+		Error:    ClassicCrossMark,
+		Success:  ClassicCheckMark,
 	}
-}
\ No newline at end of file
+}
+
+// unicodeSupported caches the result of SupportsUnicode, detected once or
+// set with SetUnicodeSupport.
+var (
+	unicodeOnce      sync.Once
+	unicodeSupported bool
+)
+
+// SupportsUnicode reports whether the terminal is expected to draw Unicode
+// glyphs such as box drawing and block characters. It is detected from the
+// locale (LC_ALL, LC_CTYPE or LANG naming UTF-8) and TERM unless set with
+// SetUnicodeSupport. Without any locale it is assumed everywhere but on
+// Windows consoles outside Windows Terminal.
+func SupportsUnicode() bool {
+	unicodeOnce.Do(func() {
+		unicodeSupported = detectUnicode()
+	})
+	return unicodeSupported
+}
+
+// SetUnicodeSupport overrides the detected Unicode support. It affects
+// components created afterwards.
+func SetUnicodeSupport(enabled bool) {
+	unicodeOnce.Do(func() {})
+	unicodeSupported = enabled
+}
+
+// detectUnicode guesses Unicode support from the environment.
+func detectUnicode() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return true // Windows Terminal
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return runtime.GOOS != "windows"
+}
@@ -1,7 +1,11 @@
 // Package style provides theming support.
 package style
 
-import "github.com/fatih/color"
+import (
+	"reflect"
+
+	"github.com/fatih/color"
+)
 
 // Theme represents a cohesive color and styling theme.
 type Theme struct {
@@ -59,6 +63,90 @@ func NewTheme() *Theme {
 	}
 }
 
+// ThemeWarning describes a problem found by Theme.Validate.
+type ThemeWarning struct {
+	// Field is the name of the theme field the warning refers to.
+	Field string
+	// Message explains the problem.
+	Message string
+}
+
+// String returns the warning in "Field: message" form.
+func (w ThemeWarning) String() string {
+	return w.Field + ": " + w.Message
+}
+
+// Validate checks the theme for unset colors, which components would otherwise
+// dereference, and for combinations that make UI elements indistinguishable,
+// such as status colors that match each other. Colors are compared for
+// equality; how well two different colors contrast is not measured. An
+// empty result means the theme is safe to use. A nil theme stands for the
+// default theme, as it does for components.
+func (t *Theme) Validate() []ThemeWarning {
+	if t == nil {
+		t = DefaultTheme()
+	}
+
+	var warnings []ThemeWarning
+
+	v := reflect.ValueOf(t).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsNil() {
+			warnings = append(warnings, ThemeWarning{
+				Field:   v.Type().Field(i).Name,
+				Message: "color is not set",
+			})
+		}
+	}
+
+	conflicts := []struct {
+		field, other string
+		a, b         *Color
+		message      string
+	}{
+		{"Selected", "Primary", t.Selected, t.Primary, "selected items are indistinguishable from regular items"},
+		{"Muted", "Primary", t.Muted, t.Primary, "muted text is indistinguishable from primary text"},
+		{"Disabled", "Primary", t.Disabled, t.Primary, "disabled items are indistinguishable from regular items"},
+		{"Disabled", "Border", t.Disabled, t.Border, "disabled items blend into borders"},
+		{"Header", "Border", t.Header, t.Border, "headers blend into borders"},
+		{"Error", "Success", t.Error, t.Success, "failures look like successes"},
+		{"Error", "Warning", t.Error, t.Warning, "errors look like warnings"},
+		{"Warning", "Success", t.Warning, t.Success, "warnings look like successes"},
+	}
+	for _, c := range conflicts {
+		if c.a != nil && c.b != nil && c.a.Equals(c.b) {
+			warnings = append(warnings, ThemeWarning{
+				Field:   c.field,
+				Message: "same as " + c.other + ": " + c.message,
+			})
+		}
+	}
+
+	return warnings
+}
+
+// WithColors returns a copy of the theme whose colors are forced on or off,
+// regardless of whether stdout is a terminal. The theme itself is unchanged.
+func (t *Theme) WithColors(enabled bool) *Theme {
+	clone := *t
+
+	v := reflect.ValueOf(&clone).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		c, ok := v.Field(i).Interface().(*Color)
+		if !ok || c == nil {
+			continue
+		}
+		copied := *c
+		if enabled {
+			copied.EnableColor()
+		} else {
+			copied.DisableColor()
+		}
+		v.Field(i).Set(reflect.ValueOf(&copied))
+	}
+	return &clone
+}
+
 // DefaultTheme returns the default cmdux theme.
 func DefaultTheme() *Theme {
 	return NewTheme()
@@ -102,13 +190,79 @@ func MonochromeTheme() *Theme {
 	theme := NewTheme()
 	theme.Primary = color.New(color.FgHiWhite, color.Bold)
 	theme.Secondary = color.New(color.FgWhite)
+	// Without hues, statuses differ by attributes
 	theme.Success = color.New(color.FgHiWhite, color.Bold)
-	theme.Warning = color.New(color.FgHiWhite, color.Bold)
-	theme.Error = color.New(color.FgHiWhite, color.Bold)
+	theme.Warning = color.New(color.FgWhite, color.Underline)
+	theme.Error = color.New(color.FgHiWhite, color.Bold, color.Underline)
 	theme.Accent1 = color.New(color.FgWhite)
 	theme.Accent2 = color.New(color.FgHiWhite)
 	theme.Accent3 = color.New(color.FgWhite)
 	theme.Border = color.New(color.FgWhite)
 	theme.Selected = color.New(color.FgHiWhite, color.Underline)
 	return theme
-}
\ No newline at end of file
+}
+
+// SunsetTheme returns a warm theme built from the 256-color palette. On
+// terminals limited to 16 colors it falls back to the nearest basic colors.
+func SunsetTheme() *Theme {
+	theme := NewTheme()
+	theme.Primary = Color256(Orange256).Add(color.Bold)
+	theme.Secondary = Color256(Gold256)
+	theme.Success = Color256(Lime256).Add(color.Bold)
+	theme.Warning = Color256(Gold256).Add(color.Bold)
+	theme.Muted = Color256(Gray256)
+	theme.Accent1 = Color256(Pink256)
+	theme.Accent2 = Color256(Purple256)
+	theme.Accent3 = Color256(Sky256)
+	theme.Border = Color256(Coral256)
+	theme.Footer = Color256(Gray256)
+	theme.Selected = Color256(Pink256).Add(color.Bold)
+	theme.Disabled = Color256(Slate256)
+	return theme
+}
+
+// AccessibleTheme returns a theme that stays readable with red-green color
+// vision deficiency (deuteranopia and protanopia). It draws from the
+// Okabe-Ito palette, telling success, warning and error apart by blue,
+// yellow and vermilion rather than green and red, and underlines the
+// selection so it does not depend on color at all. Components pair these
+// colors with symbols such as ✓ and ✗, so status never rests on color alone.
+func AccessibleTheme() *Theme {
+	theme := NewTheme()
+	theme.Primary = Color256(Sky256).Add(color.Bold)
+	theme.Secondary = Color256(Amber256)
+	theme.Success = Color256(Azure256).Add(color.Bold)
+	theme.Warning = Color256(Yellow256).Add(color.Bold)
+	theme.Error = Color256(Vermilion256).Add(color.Bold)
+	theme.Muted = Color256(Gray256)
+	theme.Accent1 = Color256(Orchid256)
+	theme.Accent2 = Color256(Amber256)
+	theme.Accent3 = Color256(Sky256)
+	theme.Border = Color256(Sky256)
+	theme.Footer = Color256(Gray256)
+	theme.Selected = Color256(Amber256).Add(color.Bold, color.Underline)
+	theme.Disabled = Color256(Slate256)
+	return theme
+}
+
+// builtinThemes maps the names of the built-in themes to their constructors.
+var builtinThemes = map[string]func() *Theme{
+	"default":    DefaultTheme,
+	"dark":       DarkTheme,
+	"light":      LightTheme,
+	"cyberpunk":  CyberpunkTheme,
+	"monochrome": MonochromeTheme,
+	"sunset":     SunsetTheme,
+	"accessible": AccessibleTheme,
+}
+
+// AvailableThemes returns the built-in themes by lower-case name, e.g.
+// "dark", so a CLI can list them in a --theme flag's help and look up the
+// user's choice. The map is a copy and may be modified.
+func AvailableThemes() map[string]func() *Theme {
+	themes := make(map[string]func() *Theme, len(builtinThemes))
+	for name, constructor := range builtinThemes {
+		themes[name] = constructor
+	}
+	return themes
+}
@@ -0,0 +1,75 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestBuiltinThemesValidate(t *testing.T) {
+	themes := map[string]*Theme{
+		"Default":    DefaultTheme(),
+		"Dark":       DarkTheme(),
+		"Light":      LightTheme(),
+		"Cyberpunk":  CyberpunkTheme(),
+		"Monochrome": MonochromeTheme(),
+		"Sunset":     SunsetTheme(),
+		"Accessible": AccessibleTheme(),
+	}
+
+	for name, theme := range themes {
+		if warnings := theme.Validate(); len(warnings) != 0 {
+			t.Errorf("%s theme has warnings: %v", name, warnings)
+		}
+	}
+}
+
+func TestThemeValidateFlagsProblems(t *testing.T) {
+	theme := NewTheme()
+	theme.Border = nil
+	theme.Selected = color.New(color.FgHiCyan, color.Bold) // same as Primary
+
+	found := map[string]bool{}
+	for _, w := range theme.Validate() {
+		found[w.Field] = true
+	}
+
+	if !found["Border"] {
+		t.Error("Expected a warning for the unset Border color")
+	}
+	if !found["Selected"] {
+		t.Error("Expected a warning for Selected matching Primary")
+	}
+
+	theme = NewTheme()
+	theme.Warning = color.New(color.FgHiRed, color.Bold) // same as Error
+	if warnings := theme.Validate(); len(warnings) != 1 || warnings[0].Field != "Error" {
+		t.Errorf("Expected one warning for Error matching Warning, got %v", warnings)
+	}
+}
+
+func TestThemeValidateNil(t *testing.T) {
+	var theme *Theme
+	if warnings := theme.Validate(); len(warnings) != 0 {
+		t.Errorf("expected a nil theme to validate as the default theme, got %v", warnings)
+	}
+}
+
+func TestAvailableThemes(t *testing.T) {
+	themes := AvailableThemes()
+	for _, name := range []string{"default", "dark", "light", "cyberpunk", "monochrome", "sunset", "accessible"} {
+		constructor, ok := themes[name]
+		if !ok {
+			t.Errorf("theme %q is missing", name)
+			continue
+		}
+		if warnings := constructor().Validate(); len(warnings) != 0 {
+			t.Errorf("%s theme has warnings: %v", name, warnings)
+		}
+	}
+
+	delete(themes, "dark")
+	if _, ok := AvailableThemes()["dark"]; !ok {
+		t.Error("modifying the returned map should not affect later calls")
+	}
+}
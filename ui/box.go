@@ -14,11 +14,74 @@ type Box struct {
 	*core.Component
 	title        string
 	content      string
+	lines        []boxLine
+	child        core.Renderable
 	padding      int
+	align        core.Alignment
+	minWidth     int
+	maxWidth     int
 	border       bool
+	corners      CornerStyle
 	borderStyle  *style.Color
 	titleStyle   *style.Color
 	contentStyle *style.Color
+	background   *style.Color
+	breakWords   bool
+	rtl          bool
+	titleWords   bool
+}
+
+// boxLine is one entry added with Line, KeyVal or Blank.
+type boxLine struct {
+	text  string
+	color *style.Color
+	key   string
+	kv    bool
+}
+
+// BoxDivider is the content line that a box draws as a horizontal rule
+// spanning its inner width, e.g. to separate a header area from the body:
+//
+//	NewBox().Content("Status: ok\n" + ui.BoxDivider + "\nDetails...")
+//
+// With a border the rule joins the sides with tees (├────┤).
+const BoxDivider = "---"
+
+// BoxStyle bundles a box's appearance so it can be defined once and applied
+// to many boxes with ApplyStyle. Nil colors fall back to the theme.
+type BoxStyle struct {
+	Border  *style.Color
+	Title   *style.Color
+	Content *style.Color
+	Corners CornerStyle
+}
+
+// CornerStyle selects the glyphs used to draw a box border.
+type CornerStyle int
+
+const (
+	// CornerRounded draws rounded corners (╭╮╰╯). This is the default.
+	CornerRounded CornerStyle = iota
+	// CornerSquare draws square corners (┌┐└┘).
+	CornerSquare
+	// CornerDouble draws double lines (╔╗╚╝).
+	CornerDouble
+	// CornerHeavy draws heavy lines (┏┓┗┛).
+	CornerHeavy
+)
+
+// BoxChars returns the box drawing characters for the corner style.
+func (cs CornerStyle) BoxChars() core.BoxChars {
+	switch cs {
+	case CornerSquare:
+		return core.SquareBoxChars()
+	case CornerDouble:
+		return core.DoubleBoxChars()
+	case CornerHeavy:
+		return core.HeavyBoxChars()
+	default:
+		return core.DefaultBoxChars()
+	}
 }
 
 // NewBox creates a new box component.
@@ -30,6 +93,15 @@ func NewBox() *Box {
 	}
 }
 
+// Clone returns an independent copy of the box for further configuration.
+// A child set with ContentComponent is shared, not copied.
+func (b *Box) Clone() *Box {
+	clone := *b
+	clone.Component = b.Component.Clone()
+	clone.lines = append([]boxLine(nil), b.lines...)
+	return &clone
+}
+
 // Title sets the box title.
 func (b *Box) Title(title string) *Box {
 	b.title = title
@@ -42,6 +114,71 @@ func (b *Box) Content(content string) *Box {
 	return b
 }
 
+// Line appends a line of content in color, or in the content color if color
+// is nil. Like Content it is wrapped and aligned to the box width, but the
+// color is applied after wrapping so every wrapped line keeps it:
+//
+//	NewBox().Title("User").
+//		Line("Registration complete", style.Success).
+//		Blank().
+//		KeyVal("Username", "alice").
+//		KeyVal("Email", "alice@example.com")
+//
+// Lines added with Line, KeyVal and Blank follow any Content.
+func (b *Box) Line(text string, color *style.Color) *Box {
+	b.lines = append(b.lines, boxLine{text: text, color: color})
+	return b
+}
+
+// KeyVal appends a "key: value" line. Values of all KeyVal lines start in
+// the same column, after the widest key, and wrap under it.
+func (b *Box) KeyVal(key, value string) *Box {
+	b.lines = append(b.lines, boxLine{text: value, key: key, kv: true})
+	return b
+}
+
+// Blank appends an empty line.
+func (b *Box) Blank() *Box {
+	return b.Line("", nil)
+}
+
+// ContentComponent sets another component as the box content, e.g. a table
+// inside a bordered panel. The child is rendered with the box's theme and
+// framed as-is: its lines are not wrapped and the box grows to fit them.
+// It takes precedence over Content.
+func (b *Box) ContentComponent(child core.Renderable) *Box {
+	b.child = child
+	return b
+}
+
+// ContentAlign sets how wrapped content lines are aligned (default
+// core.AlignLeft). core.AlignJustify fills every line of a paragraph but the last.
+func (b *Box) ContentAlign(align core.Alignment) *Box {
+	b.align = align
+	return b
+}
+
+// RTL lays out content for right-to-left scripts by mirroring the content
+// alignment, so left-aligned text hugs the right border.
+func (b *Box) RTL(enabled bool) *Box {
+	b.rtl = enabled
+	return b
+}
+
+// BreakLongWords splits words wider than the content area, such as URLs or
+// tokens, across lines instead of truncating them with "…" (the default).
+func (b *Box) BreakLongWords(enabled bool) *Box {
+	b.breakWords = enabled
+	return b
+}
+
+// TruncateTitleWords truncates a title that does not fit at a word boundary
+// ("Very Long Title…") instead of mid-word ("Very Long Tit…", the default).
+func (b *Box) TruncateTitleWords(enabled bool) *Box {
+	b.titleWords = enabled
+	return b
+}
+
 // Padding sets the internal padding.
 func (b *Box) Padding(padding int) *Box {
 	b.padding = padding
@@ -60,12 +197,32 @@ func (b *Box) Height(h int) *Box {
 	return b
 }
 
+// MinWidth sets the minimum width for auto-sized boxes. It has no effect when
+// an explicit Width is set.
+func (b *Box) MinWidth(w int) *Box {
+	b.minWidth = w
+	return b
+}
+
+// MaxWidth sets the maximum width for auto-sized boxes. Content wider than
+// this is wrapped. It has no effect when an explicit Width is set.
+func (b *Box) MaxWidth(w int) *Box {
+	b.maxWidth = w
+	return b
+}
+
 // Border enables or disables the border.
 func (b *Box) Border(enabled bool) *Box {
 	b.border = enabled
 	return b
 }
 
+// CornerStyle sets the glyphs used to draw the border.
+func (b *Box) CornerStyle(corners CornerStyle) *Box {
+	b.corners = corners
+	return b
+}
+
 // BorderStyle sets the border color.
 func (b *Box) BorderStyle(color *style.Color) *Box {
 	b.borderStyle = color
@@ -84,15 +241,77 @@ func (b *Box) ContentStyle(color *style.Color) *Box {
 	return b
 }
 
+// Background fills the box interior, padding included, with a background
+// color such as color.New(color.BgBlue), making a solid panel.
+func (b *Box) Background(color *style.Color) *Box {
+	b.background = color
+	return b
+}
+
+// ApplyStyle sets all style slots of the box from s.
+func (b *Box) ApplyStyle(s BoxStyle) *Box {
+	b.borderStyle = s.Border
+	b.titleStyle = s.Title
+	b.contentStyle = s.Content
+	b.corners = s.Corners
+	return b
+}
+
+// RenderPlain renders the box as plain text without any ANSI sequences.
+func (b *Box) RenderPlain() string {
+	return core.RenderPlain(b)
+}
+
+// Measure returns the width and height, in cells and lines, that the box
+// occupies when rendered with theme.
+func (b *Box) Measure(theme *style.Theme) (width, height int) {
+	return core.Measure(b, theme)
+}
+
 // Render renders the box using the given theme.
 func (b *Box) Render(theme *style.Theme) string {
+	return b.RenderContext(core.NewRenderContext(theme))
+}
+
+// RenderContext renders the box under ctx. A symbol set whose box glyphs
+// differ from the default rounded ones, such as style.ASCIISymbols, replaces
+// the corner style, and an auto-sized box stays within ctx.Width. A child set
+// with ContentComponent is rendered under the same context.
+func (b *Box) RenderContext(ctx core.RenderContext) string {
 	if b.IsHidden() {
 		return ""
 	}
+	theme := ctx.Theme
+
+	chars := b.corners.BoxChars()
+	if ctx.Symbols.BoxTopLeft != "" && ctx.Symbols.BoxTopLeft != style.BoxTopLeft {
+		chars = core.SymbolBoxChars(ctx.Symbols)
+	}
+
+	var childLines []string
+	if b.child != nil {
+		childCtx := ctx
+		if ctx.Width > 0 {
+			childCtx.Width = ctx.Width - (b.padding * 2) - 2
+			if childCtx.Width < 1 {
+				childCtx.Width = 1
+			}
+		}
+		childLines = strings.Split(core.RenderWithContext(b.child, childCtx), "\n")
+	}
 
 	width := b.GetWidth()
 	if width <= 0 {
-		width = b.calculateWidth()
+		width = b.clampWidth(b.calculateWidth(childLines))
+		if ctx.Width > 0 && width > ctx.Width {
+			width = ctx.Width
+		}
+	}
+	if childLines != nil {
+		// Rendered components can't be wrapped, so never cut them off
+		if minWidth := maxLineWidth(childLines) + (b.padding * 2) + 2; width < minWidth {
+			width = minWidth
+		}
 	}
 
 	height := b.GetHeight()
@@ -101,28 +320,42 @@ func (b *Box) Render(theme *style.Theme) string {
 	}
 
 	if !b.border {
-		return b.renderWithoutBorder(theme, width, height)
+		return b.renderWithoutBorder(theme, chars, width, height, childLines)
 	}
 
-	return b.renderWithBorder(theme, width, height)
+	return b.renderWithBorder(theme, chars, width, height, childLines)
 }
 
-func (b *Box) calculateWidth() int {
-	// Calculate width based on content
-	maxWidth := runewidth.StringWidth(b.title)
+func (b *Box) calculateWidth(childLines []string) int {
+	// Calculate width based on content; a bordered title also needs room
+	// for its "[ ]" brackets
+	maxWidth := core.StringWidth(b.title)
+	if b.border && b.title != "" {
+		maxWidth += 4
+	}
 
-	lines := strings.Split(b.content, "\n")
-	for _, line := range lines {
-		lineWidth := runewidth.StringWidth(line)
-		if lineWidth > maxWidth {
-			maxWidth = lineWidth
-		}
+	lines := childLines
+	if lines == nil {
+		lines = b.naturalLines()
+	}
+	if lineWidth := maxLineWidth(lines); lineWidth > maxWidth {
+		maxWidth = lineWidth
 	}
 
 	// Add padding and border
 	return maxWidth + (b.padding * 2) + 2 // 2 for border
 }
 
+func (b *Box) clampWidth(width int) int {
+	if b.maxWidth > 0 && width > b.maxWidth {
+		width = b.maxWidth
+	}
+	if b.minWidth > 0 && width < b.minWidth {
+		width = b.minWidth
+	}
+	return width
+}
+
 func (b *Box) calculateHeight(width int) int {
 	contentWidth := width - (b.padding * 2) - 2 // Account for padding and border
 	if contentWidth <= 0 {
@@ -130,7 +363,7 @@ func (b *Box) calculateHeight(width int) int {
 	}
 
 	// Count wrapped lines
-	lines := strings.Split(b.content, "\n")
+	lines := b.naturalLines()
 	totalLines := 0
 
 	for _, line := range lines {
@@ -139,7 +372,7 @@ func (b *Box) calculateHeight(width int) int {
 			continue
 		}
 
-		lineWidth := runewidth.StringWidth(line)
+		lineWidth := core.StringWidth(line)
 		wrappedLines := (lineWidth + contentWidth - 1) / contentWidth // Ceiling division
 		if wrappedLines == 0 {
 			wrappedLines = 1
@@ -156,40 +389,60 @@ func (b *Box) calculateHeight(width int) int {
 	return height
 }
 
-func (b *Box) renderWithBorder(theme *style.Theme, width, height int) string {
+// maxLineWidth returns the display width of the widest line, ignoring ANSI codes.
+func maxLineWidth(lines []string) int {
+	maxWidth := 0
+	for _, line := range lines {
+		if lineWidth := core.MeasureText(line); lineWidth > maxWidth {
+			maxWidth = lineWidth
+		}
+	}
+	return maxWidth
+}
+
+func (b *Box) renderWithBorder(theme *style.Theme, chars core.BoxChars, width, height int, childLines []string) string {
 	if width < 3 || height < 3 {
 		return b.content
 	}
 
 	borderColor := b.borderStyle
 	if borderColor == nil {
-		borderColor = theme.Border
+		borderColor = style.ColorOr(theme.Border, style.Border)
 	}
 
 	titleColor := b.titleStyle
 	if titleColor == nil {
-		titleColor = theme.Header
+		titleColor = style.ColorOr(theme.Header, style.Header)
 	}
 
 	contentColor := b.contentStyle
 	if contentColor == nil {
-		contentColor = theme.Primary
+		contentColor = style.ColorOr(theme.Primary, style.Primary)
 	}
 
+	horizontal := string(chars.Horizontal)
+	vertical := string(chars.Vertical)
+
 	var result []string
 
-	// Top border with title
-	if b.title != "" {
-		titleStr := b.title
-		titleWidth := runewidth.StringWidth(titleStr)
+	// Calculate available space for title (accounting for borders and brackets)
+	availableWidth := width - 2         // Account for left and right borders
+	maxTitleWidth := availableWidth - 4 // Account for "[ ]" brackets
 
-		// Calculate available space for title (accounting for borders and brackets)
-		availableWidth := width - 2         // Account for left and right borders
-		maxTitleWidth := availableWidth - 4 // Account for "[ ]" brackets
+	// Top border with title; boxes too narrow for any title get a plain border
+	if b.title != "" && maxTitleWidth > 0 {
+		titleStr := b.title
+		titleWidth := core.StringWidth(titleStr)
 
 		if titleWidth > maxTitleWidth {
-			titleStr = runewidth.Truncate(titleStr, maxTitleWidth, "…")
-			titleWidth = maxTitleWidth
+			// A wide character may not fit in the last cell, so measure
+			// the truncated title rather than assuming it fills the budget
+			if b.titleWords {
+				titleStr = core.NewRenderer(maxTitleWidth, 0).TruncateWords(titleStr, maxTitleWidth)
+			} else {
+				titleStr = core.TruncateCells(titleStr, maxTitleWidth)
+			}
+			titleWidth = core.StringWidth(titleStr)
 		}
 
 		// Calculate padding to center the title
@@ -197,16 +450,16 @@ func (b *Box) renderWithBorder(theme *style.Theme, width, height int) string {
 		leftPadding := totalPadding / 2
 		rightPadding := totalPadding - leftPadding
 
-		topLine := borderColor.Sprint(style.BoxTopLeft) +
-			strings.Repeat(borderColor.Sprint(style.BoxHorizontal), leftPadding) +
+		topLine := borderColor.Sprint(string(chars.TopLeft)) +
+			strings.Repeat(borderColor.Sprint(horizontal), leftPadding) +
 			borderColor.Sprint("[ ") + titleColor.Sprint(titleStr) + borderColor.Sprint(" ]") +
-			strings.Repeat(borderColor.Sprint(style.BoxHorizontal), rightPadding) +
-			borderColor.Sprint(style.BoxTopRight)
+			strings.Repeat(borderColor.Sprint(horizontal), rightPadding) +
+			borderColor.Sprint(string(chars.TopRight))
 		result = append(result, topLine)
 	} else {
-		topLine := borderColor.Sprint(style.BoxTopLeft) +
-			strings.Repeat(borderColor.Sprint(style.BoxHorizontal), width-2) +
-			borderColor.Sprint(style.BoxTopRight)
+		topLine := borderColor.Sprint(string(chars.TopLeft)) +
+			strings.Repeat(borderColor.Sprint(horizontal), width-2) +
+			borderColor.Sprint(string(chars.TopRight))
 		result = append(result, topLine)
 	}
 
@@ -216,46 +469,55 @@ func (b *Box) renderWithBorder(theme *style.Theme, width, height int) string {
 		contentWidth = 1
 	}
 
-	// Wrap and pad content
-	contentLines := b.wrapContent(contentWidth)
+	// Wrap, style and pad content; rendered components are already styled
+	contentLines := childLines
+	if contentLines == nil {
+		contentLines = b.styledContent(theme, contentColor, contentWidth)
+	}
 
 	// Add content lines (no padding rows)
 	for i := 0; i < len(contentLines); i++ {
-		line := contentColor.Sprint(contentLines[i])
+		line := contentLines[i]
+		if childLines == nil && line == BoxDivider {
+			result = append(result, borderColor.Sprint(string(chars.LeftTee)+
+				strings.Repeat(horizontal, width-2)+string(chars.RightTee)))
+			continue
+		}
 
 		// Pad line to fit width
-		lineWidth := runewidth.StringWidth(core.StripANSI(line))
+		lineWidth := core.MeasureText(line)
 		padding := contentWidth - lineWidth
 		if padding > 0 {
 			line += strings.Repeat(" ", padding)
 		}
 
-		contentLine := borderColor.Sprint(style.BoxVertical) +
-			strings.Repeat(" ", b.padding) +
-			line +
-			strings.Repeat(" ", b.padding) +
-			borderColor.Sprint(style.BoxVertical)
+		interior := strings.Repeat(" ", b.padding) + line + strings.Repeat(" ", b.padding)
+		if b.background != nil {
+			interior = b.background.Sprint(interior)
+		}
+
+		contentLine := borderColor.Sprint(vertical) + interior + borderColor.Sprint(vertical)
 		result = append(result, contentLine)
 	}
 
 	// Bottom border
-	bottomLine := borderColor.Sprint(style.BoxBottomLeft) +
-		strings.Repeat(borderColor.Sprint(style.BoxHorizontal), width-2) +
-		borderColor.Sprint(style.BoxBottomRight)
+	bottomLine := borderColor.Sprint(string(chars.BottomLeft)) +
+		strings.Repeat(borderColor.Sprint(horizontal), width-2) +
+		borderColor.Sprint(string(chars.BottomRight))
 	result = append(result, bottomLine)
 
 	return strings.Join(result, "\n")
 }
 
-func (b *Box) renderWithoutBorder(theme *style.Theme, width, height int) string {
+func (b *Box) renderWithoutBorder(theme *style.Theme, chars core.BoxChars, width, height int, childLines []string) string {
 	contentColor := b.contentStyle
 	if contentColor == nil {
-		contentColor = theme.Primary
+		contentColor = style.ColorOr(theme.Primary, style.Primary)
 	}
 
 	titleColor := b.titleStyle
 	if titleColor == nil {
-		titleColor = theme.Header
+		titleColor = style.ColorOr(theme.Header, style.Header)
 	}
 
 	var result []string
@@ -271,30 +533,153 @@ func (b *Box) renderWithoutBorder(theme *style.Theme, width, height int) string
 		contentWidth = width
 	}
 
-	contentLines := b.wrapContent(contentWidth)
+	if childLines != nil {
+		for _, line := range childLines {
+			result = append(result, b.fillLine(strings.Repeat(" ", b.padding)+line, width))
+		}
+		return strings.Join(result, "\n")
+	}
+
+	borderColor := b.borderStyle
+	if borderColor == nil {
+		borderColor = style.ColorOr(theme.Border, style.Border)
+	}
+
+	contentLines := b.styledContent(theme, contentColor, contentWidth)
 	for _, line := range contentLines {
-		paddedLine := strings.Repeat(" ", b.padding) + contentColor.Sprint(line)
-		result = append(result, paddedLine)
+		if line == BoxDivider {
+			rule := string(chars.Horizontal)
+			result = append(result, b.fillLine(borderColor.Sprint(strings.Repeat(rule, width)), width))
+			continue
+		}
+		paddedLine := strings.Repeat(" ", b.padding) + line
+		result = append(result, b.fillLine(paddedLine, width))
 	}
 
 	return strings.Join(result, "\n")
 }
 
-func (b *Box) wrapContent(width int) []string {
+// fillLine pads a borderless line to width and applies the background color.
+// Without a background the line is returned unchanged.
+func (b *Box) fillLine(line string, width int) string {
+	if b.background == nil {
+		return line
+	}
+	if padding := width - core.MeasureText(line); padding > 0 {
+		line += strings.Repeat(" ", padding)
+	}
+	return b.background.Sprint(line)
+}
+
+// entries returns the content as builder lines: Content, if set or if there
+// are no other lines, followed by those added with Line, KeyVal and Blank.
+func (b *Box) entries() []boxLine {
+	if len(b.lines) == 0 {
+		return []boxLine{{text: b.content}}
+	}
+	if b.content == "" {
+		return b.lines
+	}
+	return append([]boxLine{{text: b.content}}, b.lines...)
+}
+
+// keyColumn returns the column KeyVal values start in: one space after the
+// widest key and its colon.
+func (b *Box) keyColumn() int {
+	column := 0
+	for _, entry := range b.lines {
+		if width := core.StringWidth(entry.key + ":"); entry.kv && width >= column {
+			column = width + 1
+		}
+	}
+	return column
+}
+
+// naturalLines returns the content lines before wrapping, used for sizing.
+func (b *Box) naturalLines() []string {
+	column := b.keyColumn()
+	var lines []string
+	for _, entry := range b.entries() {
+		for i, line := range strings.Split(entry.text, "\n") {
+			switch {
+			case entry.kv && i == 0:
+				label := entry.key + ":"
+				line = label + strings.Repeat(" ", column-core.StringWidth(label)) + line
+			case entry.kv:
+				line = strings.Repeat(" ", column) + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// styledContent wraps the content to width and colors each line. Dividers
+// are left as BoxDivider for the caller to draw.
+func (b *Box) styledContent(theme *style.Theme, contentColor *style.Color, width int) []string {
+	keyColor := style.ColorOr(theme.Secondary, style.Secondary)
+	column := b.keyColumn()
+	renderer := core.NewRenderer(width, 0)
+
+	var result []string
+	for _, entry := range b.entries() {
+		if entry.kv {
+			label := entry.key + ":"
+			padding := strings.Repeat(" ", column-core.StringWidth(label))
+			valueWidth := width - column
+			if valueWidth < 1 {
+				valueWidth = 1
+			}
+			var values []string
+			for _, line := range strings.Split(entry.text, "\n") {
+				values = append(values, renderer.WrapText(line, valueWidth)...)
+			}
+			for i, value := range values {
+				prefix := strings.Repeat(" ", column)
+				if i == 0 {
+					prefix = keyColor.Sprint(label) + padding
+				}
+				result = append(result, prefix+contentColor.Sprint(value))
+			}
+			continue
+		}
+
+		color := entry.color
+		if color == nil {
+			color = contentColor
+		}
+		for _, line := range b.wrapText(entry.text, width) {
+			if line != BoxDivider {
+				line = color.Sprint(line)
+			}
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// wrapText word-wraps and aligns text to width, turning divider lines into
+// BoxDivider.
+func (b *Box) wrapText(text string, width int) []string {
 	if width <= 0 {
-		return []string{b.content}
+		return []string{text}
 	}
 
 	var result []string
-	lines := strings.Split(b.content, "\n")
+	lines := strings.Split(text, "\n")
 
 	for _, line := range lines {
 		if line == "" {
 			result = append(result, "")
 			continue
 		}
+		if strings.TrimSpace(line) == BoxDivider {
+			result = append(result, BoxDivider)
+			continue
+		}
 
 		// Simple word wrapping
+		start := len(result)
 		words := strings.Fields(line)
 		if len(words) == 0 {
 			result = append(result, "")
@@ -309,24 +694,74 @@ func (b *Box) wrapContent(width int) []string {
 			}
 			testLine += word
 
-			if runewidth.StringWidth(testLine) <= width {
+			if core.StringWidth(testLine) <= width {
 				currentLine = testLine
-			} else {
-				if currentLine != "" {
-					result = append(result, currentLine)
-					currentLine = word
-				} else {
-					// Word is longer than width, truncate it
-					result = append(result, runewidth.Truncate(word, width, "…"))
-					currentLine = ""
-				}
+				continue
+			}
+
+			if currentLine != "" {
+				result = append(result, currentLine)
+				currentLine = ""
 			}
+			if core.StringWidth(word) <= width {
+				currentLine = word
+				continue
+			}
+
+			// Word is longer than width: split it, or truncate it
+			if !b.breakWords {
+				result = append(result, core.TruncateCells(word, width))
+				continue
+			}
+			chunks := breakWord(word, width)
+			result = append(result, chunks[:len(chunks)-1]...)
+			currentLine = chunks[len(chunks)-1]
 		}
 
 		if currentLine != "" {
 			result = append(result, currentLine)
 		}
+		b.alignParagraph(result[start:], width)
 	}
 
 	return result
 }
+
+// breakWord splits word into chunks at most width cells wide. Every chunk
+// holds at least one rune, so a wide character never stalls the split.
+func breakWord(word string, width int) []string {
+	var chunks []string
+	var chunk strings.Builder
+	chunkWidth := 0
+	for _, r := range word {
+		runeWidth := runewidth.RuneWidth(r)
+		if chunkWidth+runeWidth > width && chunk.Len() > 0 {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+			chunkWidth = 0
+		}
+		chunk.WriteRune(r)
+		chunkWidth += runeWidth
+	}
+	return append(chunks, chunk.String())
+}
+
+// alignParagraph pads the wrapped lines of one paragraph in place according to
+// the content alignment.
+func (b *Box) alignParagraph(lines []string, width int) {
+	if b.align == core.AlignLeft && !b.rtl {
+		return
+	}
+
+	renderer := core.NewRenderer(width, 0)
+	for i, line := range lines {
+		align := b.align
+		if align == core.AlignJustify && i == len(lines)-1 {
+			align = core.AlignLeft
+		}
+		if b.rtl {
+			align = align.Mirrored()
+		}
+		lines[i] = renderer.PadText(line, width, align)
+	}
+}
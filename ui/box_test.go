@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
+	"github.com/fatih/color"
 )
 
 func TestBoxTitleAlignment(t *testing.T) {
@@ -56,7 +58,7 @@ func TestBoxTitleAlignment(t *testing.T) {
 
 			topLine := lines[0]
 			// Remove color codes for comparison
-			cleanLine := stripANSI(topLine)
+			cleanLine := core.StripANSI(topLine)
 
 			if cleanLine != tt.expected {
 				t.Errorf("Expected: %q, Got: %q", tt.expected, cleanLine)
@@ -78,7 +80,7 @@ func TestBoxWithoutTitle(t *testing.T) {
 	}
 
 	topLine := lines[0]
-	cleanLine := stripANSI(topLine)
+	cleanLine := core.StripANSI(topLine)
 	expected := "╭──────────────────╮"
 
 	if cleanLine != expected {
@@ -86,6 +88,40 @@ func TestBoxWithoutTitle(t *testing.T) {
 	}
 }
 
+func TestBoxCornerStyles(t *testing.T) {
+	tests := []struct {
+		name        string
+		corners     CornerStyle
+		top, bottom string
+	}{
+		{"Rounded", CornerRounded, "╭────────╮", "╰────────╯"},
+		{"Square", CornerSquare, "┌────────┐", "└────────┘"},
+		{"Double", CornerDouble, "╔════════╗", "╚════════╝"},
+		{"Heavy", CornerHeavy, "┏━━━━━━━━┓", "┗━━━━━━━━┛"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := NewBox().
+				Content("Test").
+				Width(10).
+				CornerStyle(tt.corners)
+
+			lines := strings.Split(box.Render(style.DefaultTheme()), "\n")
+			if len(lines) < 3 {
+				t.Fatal("Not enough lines generated")
+			}
+
+			if got := core.StripANSI(lines[0]); got != tt.top {
+				t.Errorf("Top: expected %q, got %q", tt.top, got)
+			}
+			if got := core.StripANSI(lines[len(lines)-1]); got != tt.bottom {
+				t.Errorf("Bottom: expected %q, got %q", tt.bottom, got)
+			}
+		})
+	}
+}
+
 func TestBoxContentAlignment(t *testing.T) {
 	box := NewBox().
 		Title("Test").
@@ -101,7 +137,7 @@ func TestBoxContentAlignment(t *testing.T) {
 
 	// Check content line (should be left-aligned)
 	contentLine := lines[1] // Skip title, no padding now
-	cleanLine := stripANSI(contentLine)
+	cleanLine := core.StripANSI(contentLine)
 
 	// Should start with border and padding, then content
 	if !strings.HasPrefix(cleanLine, "│ Left aligned content") {
@@ -151,7 +187,7 @@ func TestBoxNoExtraEmptyLines(t *testing.T) {
 			contentLines := 0
 			startedContent := false
 			for _, line := range lines {
-				cleanLine := stripANSI(line)
+				cleanLine := core.StripANSI(line)
 				if strings.HasPrefix(cleanLine, "│") && !strings.HasPrefix(cleanLine, "╭") && !strings.HasPrefix(cleanLine, "╰") {
 					// This is a content line (has vertical border but not corner)
 					if !startedContent {
@@ -169,24 +205,213 @@ func TestBoxNoExtraEmptyLines(t *testing.T) {
 	}
 }
 
-// stripANSI removes ANSI color codes from a string
-func stripANSI(str string) string {
-	var result strings.Builder
-	inEscape := false
+func TestBoxContentComponent(t *testing.T) {
+	table := NewTable().Headers("Name", "Role").AddRow("Alice", "Developer")
+	box := NewBox().Title("Team").ContentComponent(table)
 
-	for _, r := range str {
-		if r == '\x1b' {
-			inEscape = true
-			continue
+	lines := strings.Split(box.Render(style.DefaultTheme()), "\n")
+	if len(lines) != 7 { // 2 box borders + 5 table lines
+		t.Fatalf("Expected 7 lines, got %d:\n%s", len(lines), core.StripANSI(strings.Join(lines, "\n")))
+	}
+
+	width := core.MeasureText(lines[0])
+	for _, line := range lines {
+		if core.MeasureText(line) != width {
+			t.Errorf("Misaligned line %q, expected width %d", core.StripANSI(line), width)
 		}
-		if inEscape {
-			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
-				inEscape = false
+	}
+	if !strings.HasPrefix(core.StripANSI(lines[1]), "│ ╭") {
+		t.Errorf("Table not framed inside box: %q", core.StripANSI(lines[1]))
+	}
+}
+
+func TestRenderWithPartialTheme(t *testing.T) {
+	theme := &style.Theme{Primary: style.Primary}
+
+	components := map[string]interface {
+		Render(*style.Theme) string
+	}{
+		"Box":   NewBox().Title("Test").Content("Content"),
+		"Table": NewTable().Headers("A", "B").AddRow("1", "2").AddRow("3", "4"),
+		"Menu":  NewMenu().Title("Menu").OptionsWithDesc(map[string]string{"Open": "Open a file"}),
+		"List":  NewList("one", "two"),
+	}
+
+	for name, component := range components {
+		t.Run(name, func(t *testing.T) {
+			if component.Render(theme) == "" {
+				t.Error("Expected output for a partial theme")
 			}
-			continue
+		})
+	}
+}
+
+func TestBoxJustifiedContent(t *testing.T) {
+	box := NewBox().
+		Content("the quick brown fox jumps over the lazy dog").
+		Width(20).
+		ContentAlign(core.AlignJustify)
+
+	expected := strings.Join([]string{
+		"╭──────────────────╮",
+		"│ the  quick brown │",
+		"│ fox  jumps  over │",
+		"│ the lazy dog     │",
+		"╰──────────────────╯",
+	}, "\n")
+	if got := core.StripANSI(box.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestBoxBackground(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	bg := color.New(color.BgBlue)
+	lines := strings.Split(NewBox().Content("solid\npanel").Width(12).Background(bg).Render(style.DefaultTheme()), "\n")
+
+	for i, line := range lines {
+		if width := core.MeasureText(line); width != 12 {
+			t.Errorf("line %d: expected width 12, got %d", i, width)
+		}
+		interior := i > 0 && i < len(lines)-1
+		if got := strings.Contains(line, "\x1b[44m"); got != interior {
+			t.Errorf("line %d: background present = %v, want %v: %q", i, got, interior, line)
 		}
-		result.WriteRune(r)
 	}
+}
+
+func TestBoxTitleWideCharacters(t *testing.T) {
+	titles := []string{
+		"🚀 Launch",
+		"部署状态",
+		"中文标题很长很长很长很长",
+		"👩‍💻 Dev",
+		"Café ångström",
+		"ééé combining",
+	}
+
+	for _, title := range titles {
+		for width := 3; width <= 30; width++ {
+			box := NewBox().Title(title).Content("x").Width(width)
+			topLine := strings.Split(box.Render(style.DefaultTheme()), "\n")[0]
+			if got := core.MeasureText(topLine); got != width {
+				t.Errorf("title %q, width %d: top line is %d cells: %q", title, width, got, core.StripANSI(topLine))
+			}
+		}
 
-	return result.String()
+		// Auto-sized boxes fit the whole title
+		topLine := strings.Split(NewBox().Title(title).Content("x").Render(style.DefaultTheme()), "\n")[0]
+		if !strings.Contains(core.StripANSI(topLine), title) {
+			t.Errorf("auto-sized box truncated title %q: %q", title, core.StripANSI(topLine))
+		}
+	}
+}
+
+func TestBoxBreakLongWords(t *testing.T) {
+	url := "https://example.com/a/very/long/path"
+	box := NewBox().Content("see " + url + " now").Width(16)
+
+	truncated := core.StripANSI(box.Render(style.DefaultTheme()))
+	if strings.Contains(truncated, "path") {
+		t.Errorf("expected long word to be truncated by default:\n%s", truncated)
+	}
+
+	var content strings.Builder
+	lines := strings.Split(core.StripANSI(box.BreakLongWords(true).Render(style.DefaultTheme())), "\n")
+	for _, line := range lines[1 : len(lines)-1] {
+		if width := core.MeasureText(line); width != 16 {
+			t.Errorf("line %q is %d cells wide, want 16", line, width)
+		}
+		content.WriteString(strings.TrimSpace(strings.Trim(line, "│")))
+	}
+	if !strings.Contains(content.String(), url) {
+		t.Errorf("expected the full URL across lines, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestBoxRTL(t *testing.T) {
+	box := NewBox().Content("مرحبا بالعالم").Width(20).RTL(true)
+	lines := strings.Split(core.StripANSI(box.Render(style.DefaultTheme())), "\n")
+	if lines[1] != "│    مرحبا بالعالم │" {
+		t.Errorf("RTL content not right-aligned: %q", lines[1])
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	theme := style.DefaultTheme()
+	tests := []struct {
+		name          string
+		measure       func(*style.Theme) (int, int)
+		width, height int
+	}{
+		{"box", NewBox().Title("Hi").Content("one\ntwo").Width(12).Measure, 12, 4},
+		{"table", NewTable().Headers("A", "B").AddRow("1", "2").Measure, 9, 5},
+		{"menu", NewMenu().Options("Start", "Stop").Measure, 7, 2},
+	}
+
+	for _, tt := range tests {
+		width, height := tt.measure(theme)
+		if width != tt.width || height != tt.height {
+			t.Errorf("%s: expected %dx%d, got %dx%d", tt.name, tt.width, tt.height, width, height)
+		}
+	}
+}
+
+func TestBoxDivider(t *testing.T) {
+	content := "Status: ok\n" + BoxDivider + "\nAll systems go"
+	tests := []struct {
+		corners CornerStyle
+		divider string
+	}{
+		{CornerRounded, "├──────────────────┤"},
+		{CornerDouble, "╠══════════════════╣"},
+		{CornerHeavy, "┣━━━━━━━━━━━━━━━━━━┫"},
+	}
+
+	for _, tt := range tests {
+		lines := strings.Split(core.StripANSI(NewBox().Content(content).Width(20).CornerStyle(tt.corners).Render(style.DefaultTheme())), "\n")
+		if len(lines) != 5 || lines[2] != tt.divider {
+			t.Errorf("expected divider %q on line 2, got:\n%s", tt.divider, strings.Join(lines, "\n"))
+		}
+	}
+
+	lines := strings.Split(core.StripANSI(NewBox().Content(content).Width(10).Border(false).Padding(0).Render(style.DefaultTheme())), "\n")
+	if lines[1] != "──────────" {
+		t.Errorf("expected a full-width rule without a border, got %q", lines[1])
+	}
+}
+
+func TestBoxContentBuilder(t *testing.T) {
+	box := NewBox().
+		Line("Welcome back", nil).
+		Blank().
+		KeyVal("Name", "Alice").
+		KeyVal("Email", "alice@example.com")
+
+	got := core.StripANSI(box.Render(style.DefaultTheme()))
+	lines := strings.Split(got, "\n")
+	if len(lines) != 6 || lines[3] != "│ Name:  Alice             │" || lines[4] != "│ Email: alice@example.com │" {
+		t.Errorf("unexpected builder layout:\n%s", got)
+	}
+
+	// Long values wrap under the value column
+	lines = strings.Split(core.StripANSI(box.Clone().Width(20).Render(style.DefaultTheme())), "\n")
+	for _, line := range lines {
+		if width := core.MeasureText(line); width != 20 {
+			t.Errorf("line %q is %d cells wide, want 20", line, width)
+		}
+	}
+
+	// Per-line colors survive wrapping
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+	red := color.New(color.FgRed)
+	rendered := NewBox().Line("one two three", red).Width(10).Render(style.DefaultTheme())
+	if !strings.Contains(rendered, red.Sprint("one")) || !strings.Contains(rendered, red.Sprint("three")) {
+		t.Errorf("expected every wrapped line in red:\n%q", rendered)
+	}
 }
@@ -0,0 +1,123 @@
+// Package ui provides dense multi-column layouts.
+package ui
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+)
+
+// columnGap is the number of spaces between columns.
+const columnGap = 2
+
+// ColumnOrder sets how Columns fills the grid.
+type ColumnOrder int
+
+const (
+	// ColumnMajor fills each column top to bottom before the next, like ls.
+	ColumnMajor ColumnOrder = iota
+	// RowMajor fills each row left to right before the next.
+	RowMajor
+)
+
+// Columns arranges items into as many columns as fit within termWidth, like
+// the Unix column command, filling them column by column. Each column is as
+// wide as its widest item; items wider than termWidth are truncated with "…".
+// A termWidth of 0 uses the terminal width.
+func Columns(items []string, termWidth int) string {
+	return ColumnsOrdered(items, termWidth, ColumnMajor)
+}
+
+// ColumnsOrdered is like Columns with an explicit fill order.
+func ColumnsOrdered(items []string, termWidth int, order ColumnOrder) string {
+	if len(items) == 0 {
+		return ""
+	}
+	if termWidth <= 0 {
+		termWidth, _ = core.GetTerminalSize()
+	}
+
+	cells := make([]string, len(items))
+	widths := make([]int, len(items))
+	for i, item := range items {
+		cells[i] = item
+		if width := core.MeasureText(item); width > termWidth {
+			cells[i] = core.TruncateCells(core.StripANSI(item), termWidth)
+		}
+		widths[i] = core.MeasureText(cells[i])
+	}
+
+	// Use the most columns that fit, trying from one item per column down
+	rows, colWidths := len(cells), []int{maxOf(widths)}
+	for cols := len(cells); cols > 1; cols-- {
+		r := (len(cells) + cols - 1) / cols
+		if order == ColumnMajor && (len(cells)+r-1)/r < cols {
+			continue // Same rows as a grid with fewer columns, one left empty
+		}
+		if cw := columnWidths(widths, r, cols, order); fitsWidth(cw, termWidth) {
+			rows, colWidths = r, cw
+			break
+		}
+	}
+
+	lines := make([]string, rows)
+	for row := range lines {
+		var line strings.Builder
+		for col := range colWidths {
+			i := cellIndex(row, col, rows, len(colWidths), order)
+			if i >= len(cells) {
+				continue
+			}
+			if col > 0 {
+				line.WriteString(strings.Repeat(" ", columnGap))
+			}
+			line.WriteString(cells[i])
+			if next := cellIndex(row, col+1, rows, len(colWidths), order); col+1 < len(colWidths) && next < len(cells) {
+				line.WriteString(strings.Repeat(" ", colWidths[col]-widths[i]))
+			}
+		}
+		lines[row] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cellIndex returns the item index at row and col of a rows x cols grid.
+func cellIndex(row, col, rows, cols int, order ColumnOrder) int {
+	if order == RowMajor {
+		return row*cols + col
+	}
+	return col*rows + row
+}
+
+// columnWidths returns the width of each column of a rows x cols grid.
+func columnWidths(widths []int, rows, cols int, order ColumnOrder) []int {
+	colWidths := make([]int, cols)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if i := cellIndex(row, col, rows, cols, order); i < len(widths) && widths[i] > colWidths[col] {
+				colWidths[col] = widths[i]
+			}
+		}
+	}
+	return colWidths
+}
+
+// fitsWidth reports whether columns of the given widths fit within width.
+func fitsWidth(colWidths []int, width int) bool {
+	total := columnGap * (len(colWidths) - 1)
+	for _, w := range colWidths {
+		total += w
+	}
+	return total <= width
+}
+
+// maxOf returns the largest value, or 0 for none.
+func maxOf(values []int) int {
+	largest := 0
+	for _, v := range values {
+		if v > largest {
+			largest = v
+		}
+	}
+	return largest
+}
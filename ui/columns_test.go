@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	items := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta"}
+
+	tests := []struct {
+		name     string
+		width    int
+		order    ColumnOrder
+		expected []string
+	}{
+		{"column-major", 30, ColumnMajor, []string{
+			"alpha  gamma  epsilon  eta",
+			"beta   delta  zeta",
+		}},
+		{"row-major", 30, RowMajor, []string{
+			"alpha    beta  gamma  delta",
+			"epsilon  zeta  eta",
+		}},
+		{"narrow", 16, ColumnMajor, []string{
+			"alpha  epsilon",
+			"beta   zeta",
+			"gamma  eta",
+			"delta",
+		}},
+		{"single column", 8, ColumnMajor, items},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ColumnsOrdered(items, tt.width, tt.order)
+			if expected := strings.Join(tt.expected, "\n"); got != expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+			}
+		})
+	}
+}
+
+func TestColumnsWideItems(t *testing.T) {
+	got := Columns([]string{"a-very-long-file-name.txt", "部署状态", "b"}, 10)
+	expected := "a-very-lo…\n部署状态\nb"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if Columns(nil, 10) != "" {
+		t.Error("expected no output for no items")
+	}
+}
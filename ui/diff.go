@@ -0,0 +1,180 @@
+// Package ui provides diff components.
+package ui
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// diffOp identifies how a line changed between the old and new text.
+type diffOp int
+
+const (
+	diffContext diffOp = iota
+	diffAdded
+	diffRemoved
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// Diff renders a line-based diff between two texts: added lines in green with
+// "+", removed lines in red with "-" and unchanged lines muted.
+type Diff struct {
+	*core.Component
+	oldText      string
+	newText      string
+	framed       bool
+	title        string
+	addedStyle   *style.Color
+	removedStyle *style.Color
+	contextStyle *style.Color
+}
+
+// NewDiff creates a diff component comparing oldText to newText.
+func NewDiff(oldText, newText string) *Diff {
+	return &Diff{
+		Component: core.NewComponent(),
+		oldText:   oldText,
+		newText:   newText,
+	}
+}
+
+// Clone returns an independent copy of the diff.
+func (d *Diff) Clone() *Diff {
+	clone := *d
+	clone.Component = d.Component.Clone()
+	return &clone
+}
+
+// Framed draws the diff inside a box.
+func (d *Diff) Framed(framed bool) *Diff {
+	d.framed = framed
+	return d
+}
+
+// Title sets the box title used when the diff is framed.
+func (d *Diff) Title(title string) *Diff {
+	d.title = title
+	return d
+}
+
+// AddedStyle sets the color of added lines.
+func (d *Diff) AddedStyle(color *style.Color) *Diff {
+	d.addedStyle = color
+	return d
+}
+
+// RemovedStyle sets the color of removed lines.
+func (d *Diff) RemovedStyle(color *style.Color) *Diff {
+	d.removedStyle = color
+	return d
+}
+
+// ContextStyle sets the color of unchanged lines.
+func (d *Diff) ContextStyle(color *style.Color) *Diff {
+	d.contextStyle = color
+	return d
+}
+
+// Render renders the diff using the given theme.
+func (d *Diff) Render(theme *style.Theme) string {
+	if d.IsHidden() {
+		return ""
+	}
+
+	addedColor := d.addedStyle
+	if addedColor == nil {
+		addedColor = style.ColorOr(theme.Success, style.Success)
+	}
+
+	removedColor := d.removedStyle
+	if removedColor == nil {
+		removedColor = style.ColorOr(theme.Error, style.Error)
+	}
+
+	contextColor := d.contextStyle
+	if contextColor == nil {
+		contextColor = style.ColorOr(theme.Muted, style.Muted)
+	}
+
+	var result []string
+	for _, line := range diffLines(splitLines(d.oldText), splitLines(d.newText)) {
+		switch line.op {
+		case diffAdded:
+			result = append(result, addedColor.Sprint("+ "+line.text))
+		case diffRemoved:
+			result = append(result, removedColor.Sprint("- "+line.text))
+		default:
+			result = append(result, contextColor.Sprint("  "+line.text))
+		}
+	}
+
+	output := strings.Join(result, "\n")
+	if !d.framed {
+		return output
+	}
+
+	return NewBox().
+		Title(d.title).
+		ContentComponent(core.Text(output)).
+		Render(theme)
+}
+
+// splitLines splits text into lines, treating empty text as no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes a line diff from the longest common subsequence of the
+// two inputs. Removals are listed before additions within a changed block.
+func diffLines(oldLines, newLines []string) []diffLine {
+	// lcs[i][j] is the LCS length of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var result []diffLine
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			result = append(result, diffLine{diffContext, oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{diffRemoved, oldLines[i]})
+			i++
+		default:
+			result = append(result, diffLine{diffAdded, newLines[j]})
+			j++
+		}
+	}
+	for ; i < len(oldLines); i++ {
+		result = append(result, diffLine{diffRemoved, oldLines[i]})
+	}
+	for ; j < len(newLines); j++ {
+		result = append(result, diffLine{diffAdded, newLines[j]})
+	}
+
+	return result
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestDiffRender(t *testing.T) {
+	oldText := "name: cmdux\nversion: 1.0\nlicense: MIT\n"
+	newText := "name: cmdux\nversion: 1.1\nlicense: MIT\nauthor: bagaking\n"
+
+	expected := strings.Join([]string{
+		"  name: cmdux",
+		"- version: 1.0",
+		"+ version: 1.1",
+		"  license: MIT",
+		"+ author: bagaking",
+	}, "\n")
+
+	if got := core.StripANSI(NewDiff(oldText, newText).Render(style.DefaultTheme())); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
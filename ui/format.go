@@ -0,0 +1,56 @@
+// Package ui provides cell formatters for tables.
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NumberFormatter returns a cell formatter that groups the integer digits of
+// numeric cells in threes with the thousands separator and writes the decimal
+// point as decimal, e.g. NumberFormatter(",", ".") turns "1234567.89" into
+// "1,234,567.89" and NumberFormatter(".", ",") into "1.234.567,89".
+// Cells that are not plain numbers are returned unchanged.
+func NumberFormatter(thousands, decimal string) func(string) string {
+	return func(cell string) string {
+		value := strings.TrimSpace(cell)
+		if _, err := strconv.ParseFloat(value, 64); err != nil || strings.ContainsAny(value, "eEnN") {
+			return cell
+		}
+
+		sign := ""
+		if value[0] == '-' || value[0] == '+' {
+			sign, value = value[:1], value[1:]
+		}
+
+		integer, fraction, hasFraction := strings.Cut(value, ".")
+		var grouped strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				grouped.WriteString(thousands)
+			}
+			grouped.WriteRune(digit)
+		}
+
+		result := sign + grouped.String()
+		if hasFraction {
+			result += decimal + fraction
+		}
+		return result
+	}
+}
+
+// DateFormatter returns a cell formatter that parses cells with the layout
+// from and writes them with the layout to, e.g.
+// DateFormatter(time.RFC3339, "Jan 2, 2006"). Cells that do not parse are
+// returned unchanged.
+func DateFormatter(from, to string) func(string) string {
+	return func(cell string) string {
+		parsed, err := time.Parse(from, strings.TrimSpace(cell))
+		if err != nil {
+			return cell
+		}
+		return parsed.Format(to)
+	}
+}
@@ -0,0 +1,210 @@
+// Package ui provides gauge components.
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// defaultGaugeWidth is the meter width used when no width is set.
+const defaultGaugeWidth = 30
+
+// Default gauge glyphs, replaced by a RenderContext's ASCII symbols.
+const (
+	defaultGaugeTrack  = "━"
+	defaultGaugeMarker = "●"
+)
+
+// GaugeZone colors the part of a gauge's range up to and including Upto.
+type GaugeZone struct {
+	Upto  float64
+	Color *style.Color
+}
+
+// Gauge shows a value within a range as a horizontal meter with a marker at
+// the value's position, e.g. for temperature or load on a dashboard. Unlike
+// ProgressBar it does not track the completion of a task.
+type Gauge struct {
+	*core.Component
+	value     float64
+	min       float64
+	max       float64
+	label     string
+	format    string
+	track     string
+	marker    string
+	zones     []GaugeZone
+	warn      float64
+	crit      float64
+	threshold bool
+}
+
+// NewGauge creates a gauge showing value within [min, max].
+func NewGauge(value, min, max float64) *Gauge {
+	return &Gauge{
+		Component: core.NewComponent(),
+		value:     value,
+		min:       min,
+		max:       max,
+		format:    "%.1f",
+		track:     defaultGaugeTrack,
+		marker:    defaultGaugeMarker,
+	}
+}
+
+// Clone returns an independent copy of the gauge.
+func (g *Gauge) Clone() *Gauge {
+	clone := *g
+	clone.Component = g.Component.Clone()
+	clone.zones = append([]GaugeZone(nil), g.zones...)
+	return &clone
+}
+
+// Value sets the displayed value.
+func (g *Gauge) Value(value float64) *Gauge {
+	g.value = value
+	return g
+}
+
+// Label sets the text shown before the meter.
+func (g *Gauge) Label(label string) *Gauge {
+	g.label = label
+	return g
+}
+
+// Format sets the fmt verb used to print the value after the meter (default
+// "%.1f"), e.g. "%.0f°C". An empty format hides the value.
+func (g *Gauge) Format(format string) *Gauge {
+	g.format = format
+	return g
+}
+
+// Chars sets the track and marker glyphs (default "━" and "●"). Both should
+// be one cell wide.
+func (g *Gauge) Chars(track, marker string) *Gauge {
+	g.track = track
+	g.marker = marker
+	return g
+}
+
+// Width sets the width of the meter itself, excluding label and value.
+func (g *Gauge) Width(w int) *Gauge {
+	g.Component.Width(w)
+	return g
+}
+
+// Zone adds a colored zone covering the range from the previous zone up to
+// upto. Values above the last zone use its color.
+func (g *Gauge) Zone(upto float64, color *style.Color) *Gauge {
+	g.zones = append(g.zones, GaugeZone{Upto: upto, Color: color})
+	return g
+}
+
+// Thresholds colors the meter green up to warn, yellow up to crit and red
+// above, using the theme's success, warning and error colors. Explicit zones
+// take precedence.
+func (g *Gauge) Thresholds(warn, crit float64) *Gauge {
+	g.warn = warn
+	g.crit = crit
+	g.threshold = true
+	return g
+}
+
+// RenderContext renders the gauge under ctx. With a symbol set whose box
+// glyphs differ from the default ones, such as style.ASCIISymbols, a track
+// and marker left at their defaults become its BoxHorizontal and Bullet
+// glyphs.
+func (g *Gauge) RenderContext(ctx core.RenderContext) string {
+	if ctx.Symbols.BoxHorizontal != "" && ctx.Symbols.BoxHorizontal != style.BoxHorizontal {
+		gauge := *g
+		if g.track == defaultGaugeTrack {
+			gauge.track = ctx.Symbols.BoxHorizontal
+		}
+		if g.marker == defaultGaugeMarker && ctx.Symbols.Bullet != "" {
+			gauge.marker = ctx.Symbols.Bullet
+		}
+		return gauge.Render(ctx.Theme)
+	}
+	return g.Render(ctx.Theme)
+}
+
+// Render renders the gauge using the given theme.
+func (g *Gauge) Render(theme *style.Theme) string {
+	if g.IsHidden() {
+		return ""
+	}
+
+	width := g.GetWidth()
+	if width <= 0 {
+		width = defaultGaugeWidth
+	}
+
+	zones := g.resolveZones(theme)
+	trackColor := style.ColorOr(theme.Muted, style.Muted)
+	markerColor := style.ColorOr(theme.Primary, style.Primary)
+	if len(zones) > 0 {
+		markerColor = zoneColor(zones, g.value)
+	}
+
+	position := int(math.Round(g.ratio() * float64(width-1)))
+
+	var meter strings.Builder
+	for x := 0; x < width; x++ {
+		if x == position {
+			meter.WriteString(markerColor.Sprint(g.marker))
+			continue
+		}
+
+		color := trackColor
+		if len(zones) > 0 {
+			cellValue := g.min + (float64(x)+0.5)/float64(width)*(g.max-g.min)
+			color = zoneColor(zones, cellValue)
+		}
+		meter.WriteString(color.Sprint(g.track))
+	}
+
+	var result strings.Builder
+	if g.label != "" {
+		result.WriteString(style.ColorOr(theme.Secondary, style.Secondary).Sprint(g.label) + " ")
+	}
+	result.WriteString(meter.String())
+	if g.format != "" {
+		result.WriteString(" " + markerColor.Sprintf(g.format, g.value))
+	}
+
+	return result.String()
+}
+
+// ratio returns the value's position within the range, clamped to [0, 1].
+func (g *Gauge) ratio() float64 {
+	if g.max <= g.min {
+		return 0
+	}
+	return math.Max(0, math.Min(1, (g.value-g.min)/(g.max-g.min)))
+}
+
+// resolveZones returns the explicit zones, or the threshold zones built from
+// the theme.
+func (g *Gauge) resolveZones(theme *style.Theme) []GaugeZone {
+	if len(g.zones) > 0 || !g.threshold {
+		return g.zones
+	}
+	return []GaugeZone{
+		{Upto: g.warn, Color: style.ColorOr(theme.Success, style.Success)},
+		{Upto: g.crit, Color: style.ColorOr(theme.Warning, style.Warning)},
+		{Upto: math.Inf(1), Color: style.ColorOr(theme.Error, style.Error)},
+	}
+}
+
+// zoneColor returns the color of the first zone containing value.
+func zoneColor(zones []GaugeZone, value float64) *style.Color {
+	for _, zone := range zones {
+		if value <= zone.Upto {
+			return zone.Color
+		}
+	}
+	return zones[len(zones)-1].Color
+}
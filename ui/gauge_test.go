@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestGaugeMarkerPosition(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "●────────── 0"},
+		{50, "─────●───── 50"},
+		{100, "──────────● 100"},
+		{150, "──────────● 150"},
+		{-20, "●────────── -20"},
+	}
+
+	for _, tt := range tests {
+		gauge := NewGauge(tt.value, 0, 100).Width(11).Chars("─", "●").Format("%.0f")
+		if got := core.StripANSI(gauge.Render(style.DefaultTheme())); got != tt.expected {
+			t.Errorf("value %v: expected %q, got %q", tt.value, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package ui provides key/value display components.
+package ui
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// KeyValue renders aligned key/value pairs, like `kubectl describe`.
+type KeyValue struct {
+	*core.Component
+	keys       []string
+	values     []string
+	separator  string
+	keyStyle   *style.Color
+	valueStyle *style.Color
+}
+
+// NewKeyValue creates a new key/value component.
+func NewKeyValue() *KeyValue {
+	return &KeyValue{
+		Component: core.NewComponent(),
+		separator: ":",
+	}
+}
+
+// Clone returns an independent copy of the component.
+func (kv *KeyValue) Clone() *KeyValue {
+	clone := *kv
+	clone.Component = kv.Component.Clone()
+	clone.keys = append([]string(nil), kv.keys...)
+	clone.values = append([]string(nil), kv.values...)
+	return &clone
+}
+
+// Add appends a key/value pair. Pairs are rendered in insertion order.
+func (kv *KeyValue) Add(key, value string) *KeyValue {
+	kv.keys = append(kv.keys, key)
+	kv.values = append(kv.values, value)
+	return kv
+}
+
+// Separator sets the string placed directly after each key (default ":").
+func (kv *KeyValue) Separator(separator string) *KeyValue {
+	kv.separator = separator
+	return kv
+}
+
+// Width sets the total width used for wrapping long values.
+func (kv *KeyValue) Width(w int) *KeyValue {
+	kv.Component.Width(w)
+	return kv
+}
+
+// KeyStyle sets the key color.
+func (kv *KeyValue) KeyStyle(color *style.Color) *KeyValue {
+	kv.keyStyle = color
+	return kv
+}
+
+// ValueStyle sets the value color.
+func (kv *KeyValue) ValueStyle(color *style.Color) *KeyValue {
+	kv.valueStyle = color
+	return kv
+}
+
+// RenderContext renders the key/value pairs under ctx. Without a width of
+// its own the component wraps values to ctx.Width.
+func (kv *KeyValue) RenderContext(ctx core.RenderContext) string {
+	if ctx.Width > 0 && kv.GetWidth() <= 0 {
+		kv = kv.Clone()
+		kv.Component.Width(ctx.Width)
+	}
+	return kv.Render(ctx.Theme)
+}
+
+// Render renders the key/value pairs using the given theme.
+func (kv *KeyValue) Render(theme *style.Theme) string {
+	if kv.IsHidden() || len(kv.keys) == 0 {
+		return ""
+	}
+
+	keyColor := kv.keyStyle
+	if keyColor == nil {
+		keyColor = style.ColorOr(theme.Secondary, style.Secondary)
+	}
+
+	valueColor := kv.valueStyle
+	if valueColor == nil {
+		valueColor = style.ColorOr(theme.Primary, style.Primary)
+	}
+
+	// Values start one space after the widest key and its separator
+	labelWidth := 0
+	for _, key := range kv.keys {
+		if width := core.StringWidth(key + kv.separator); width > labelWidth {
+			labelWidth = width
+		}
+	}
+	valueColumn := labelWidth + 1
+	hangingIndent := strings.Repeat(" ", valueColumn)
+
+	renderer := core.NewRenderer(kv.GetWidth(), 0)
+	valueWidth := kv.GetWidth() - valueColumn
+
+	var result []string
+	for i, key := range kv.keys {
+		label := key + kv.separator
+		padding := strings.Repeat(" ", valueColumn-core.StringWidth(label))
+
+		for j, line := range kv.valueLines(renderer, kv.values[i], valueWidth) {
+			prefix := hangingIndent
+			if j == 0 {
+				prefix = keyColor.Sprint(label) + padding
+			}
+			result = append(result, prefix+valueColor.Sprint(line))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// valueLines splits a value on newlines and wraps each line when a width is set.
+func (kv *KeyValue) valueLines(renderer *core.Renderer, value string, width int) []string {
+	lines := strings.Split(value, "\n")
+	if kv.GetWidth() <= 0 {
+		return lines
+	}
+	if width < 1 {
+		width = 1
+	}
+
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, renderer.WrapText(line, width)...)
+	}
+	return wrapped
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestKeyValueAlignment(t *testing.T) {
+	kv := NewKeyValue().Add("Name", "cmdux").Add("Version", "1.0.0").Add("Notes", "first\nsecond")
+
+	expected := strings.Join([]string{
+		"Name:    cmdux",
+		"Version: 1.0.0",
+		"Notes:   first",
+		"         second",
+	}, "\n")
+	if got := core.StripANSI(kv.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := core.StripANSI(NewKeyValue().Add("a", "1").Add("bb", "2").Separator(" =").Render(style.DefaultTheme())); got != "a =  1\nbb = 2" {
+		t.Errorf("custom separator: got %q", got)
+	}
+}
+
+func TestKeyValueWrapping(t *testing.T) {
+	kv := NewKeyValue().Add("Desc", "one two three four").Width(14)
+
+	expected := "Desc: one two\n      three\n      four"
+	if got := core.StripANSI(kv.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	ctx := core.NewRenderContext(style.DefaultTheme())
+	ctx.Width = 14
+	if got := core.StripANSI(NewKeyValue().Add("Desc", "one two three four").RenderContext(ctx)); got != expected {
+		t.Errorf("expected ctx.Width to wrap like Width, got:\n%s", got)
+	}
+}
+
+func TestKeyValueWideKeys(t *testing.T) {
+	kv := NewKeyValue().Add("名前", "cmdux").Add("ID", "42")
+
+	lines := strings.Split(core.StripANSI(kv.Render(style.DefaultTheme())), "\n")
+	if lines[0] != "名前: cmdux" || lines[1] != "ID:   42" {
+		t.Errorf("expected values aligned by display width, got %q", lines)
+	}
+}
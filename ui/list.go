@@ -0,0 +1,168 @@
+// Package ui provides list components.
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// List represents a bulleted or numbered list.
+type List struct {
+	*core.Component
+	items       []string
+	ordered     bool
+	bullet      string
+	indent      int
+	bulletStyle *style.Color
+	itemStyle   *style.Color
+}
+
+// NewList creates a new list component with the given items.
+func NewList(items ...string) *List {
+	return &List{
+		Component: core.NewComponent(),
+		items:     items,
+		bullet:    style.Bullet,
+	}
+}
+
+// Clone returns an independent copy of the list.
+func (l *List) Clone() *List {
+	clone := *l
+	clone.Component = l.Component.Clone()
+	clone.items = append([]string(nil), l.items...)
+	return &clone
+}
+
+// Items sets the list items.
+func (l *List) Items(items ...string) *List {
+	l.items = items
+	return l
+}
+
+// AddItem appends a single item to the list.
+func (l *List) AddItem(item string) *List {
+	l.items = append(l.items, item)
+	return l
+}
+
+// Ordered switches between a numbered (true) and a bulleted (false) list.
+func (l *List) Ordered(ordered bool) *List {
+	l.ordered = ordered
+	return l
+}
+
+// BulletChar sets the marker used for unordered items.
+func (l *List) BulletChar(bullet string) *List {
+	l.bullet = bullet
+	return l
+}
+
+// Indent sets the number of spaces before each marker.
+func (l *List) Indent(indent int) *List {
+	if indent >= 0 {
+		l.indent = indent
+	}
+	return l
+}
+
+// Width sets the total list width used for wrapping long items.
+func (l *List) Width(w int) *List {
+	l.Component.Width(w)
+	return l
+}
+
+// BulletStyle sets the marker color.
+func (l *List) BulletStyle(color *style.Color) *List {
+	l.bulletStyle = color
+	return l
+}
+
+// ItemStyle sets the item text color.
+func (l *List) ItemStyle(color *style.Color) *List {
+	l.itemStyle = color
+	return l
+}
+
+// RenderContext renders the list under ctx. A bullet left at its default
+// becomes the Bullet glyph of ctx.Symbols, and without a width of its own the
+// list wraps items to ctx.Width.
+func (l *List) RenderContext(ctx core.RenderContext) string {
+	list := l.Clone()
+	if ctx.Symbols.Bullet != "" && l.bullet == style.Bullet {
+		list.bullet = ctx.Symbols.Bullet
+	}
+	if ctx.Width > 0 && l.GetWidth() <= 0 {
+		list.Component.Width(ctx.Width)
+	}
+	return list.Render(ctx.Theme)
+}
+
+// Render renders the list using the given theme.
+func (l *List) Render(theme *style.Theme) string {
+	if l.IsHidden() || len(l.items) == 0 {
+		return ""
+	}
+
+	bulletColor := l.bulletStyle
+	if bulletColor == nil {
+		bulletColor = style.ColorOr(theme.Secondary, style.Secondary)
+	}
+
+	itemColor := l.itemStyle
+	if itemColor == nil {
+		itemColor = style.ColorOr(theme.Primary, style.Primary)
+	}
+
+	// All markers share the same width so wrapped lines hang consistently
+	markers := make([]string, len(l.items))
+	markerWidth := 0
+	for i := range l.items {
+		markers[i] = l.marker(i)
+		if w := core.MeasureText(markers[i]); w > markerWidth {
+			markerWidth = w
+		}
+	}
+
+	renderer := core.NewRenderer(l.GetWidth(), 0)
+	hangingIndent := strings.Repeat(" ", l.indent+markerWidth+1)
+	textWidth := l.GetWidth() - l.indent - markerWidth - 1
+
+	var result []string
+	for i, item := range l.items {
+		lines := []string{item}
+		if l.GetWidth() > 0 {
+			if textWidth < 1 {
+				textWidth = 1
+			}
+			lines = renderer.WrapText(item, textWidth)
+		}
+
+		// Numbers are right-aligned so the dots line up, bullets left-aligned
+		align := core.AlignLeft
+		if l.ordered {
+			align = core.AlignRight
+		}
+		marker := renderer.PadText(markers[i], markerWidth, align)
+
+		for j, line := range lines {
+			prefix := hangingIndent
+			if j == 0 {
+				prefix = strings.Repeat(" ", l.indent) + bulletColor.Sprint(marker) + " "
+			}
+			result = append(result, prefix+itemColor.Sprint(line))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (l *List) marker(index int) string {
+	if l.ordered {
+		return strconv.Itoa(index+1) + "."
+	}
+	return l.bullet
+}
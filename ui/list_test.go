@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestListMarkers(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	ordered := core.StripANSI(NewList(items...).Ordered(true).Render(style.DefaultTheme()))
+	lines := strings.Split(ordered, "\n")
+	if lines[0] != " 1. a" || lines[9] != "10. j" {
+		t.Errorf("Ordered markers not right-aligned: %q", lines)
+	}
+
+	bulleted := core.StripANSI(NewList("a", "b").BulletChar("-").Indent(2).Render(style.DefaultTheme()))
+	if bulleted != "  - a\n  - b" {
+		t.Errorf("Unexpected bulleted output: %q", bulleted)
+	}
+}
+
+func TestListHangingIndent(t *testing.T) {
+	list := NewList("one two three four").BulletChar("*").Width(11)
+
+	result := core.StripANSI(list.Render(style.DefaultTheme()))
+	expected := "* one two\n  three\n  four"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
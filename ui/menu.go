@@ -6,7 +6,13 @@ import (
 
 	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
-	"github.com/mattn/go-runewidth"
+)
+
+// defaultSelectedPrefix and defaultPrefix mark the selected and the other
+// options unless SelectedPrefix or Prefix set different ones.
+const (
+	defaultSelectedPrefix = "▶ "
+	defaultPrefix         = "  "
 )
 
 // Menu represents an interactive menu component.
@@ -22,6 +28,10 @@ type Menu struct {
 	optionStyle *style.Color
 	selectedStyle *style.Color
 	descStyle   *style.Color
+	maxOptionWidth int
+	wrapDesc    bool
+	matcher     core.Matcher
+	onSelect    func(index int, option string)
 }
 
 // NewMenu creates a new menu component.
@@ -29,11 +39,20 @@ func NewMenu() *Menu {
 	return &Menu{
 		Component:      core.NewComponent(),
 		selected:       0,
-		prefix:         "  ",
-		selectedPrefix: "▶ ",
+		prefix:         defaultPrefix,
+		selectedPrefix: defaultSelectedPrefix,
 	}
 }
 
+// Clone returns an independent copy of the menu.
+func (m *Menu) Clone() *Menu {
+	clone := *m
+	clone.Component = m.Component.Clone()
+	clone.options = append([]string(nil), m.options...)
+	clone.descriptions = append([]string(nil), m.descriptions...)
+	return &clone
+}
+
 // Title sets the menu title.
 func (m *Menu) Title(title string) *Menu {
 	m.title = title
@@ -61,11 +80,31 @@ func (m *Menu) OptionsWithDesc(optionsDesc map[string]string) *Menu {
 // Selected sets the currently selected option index.
 func (m *Menu) Selected(index int) *Menu {
 	if index >= 0 && index < len(m.options) {
-		m.selected = index
+		m.moveTo(index)
 	}
 	return m
 }
 
+// OnSelect sets a function called whenever the selection moves, with the
+// newly selected index and option, e.g. to update a preview pane as the
+// user navigates with SelectNext and SelectPrev.
+func (m *Menu) OnSelect(fn func(index int, option string)) *Menu {
+	m.onSelect = fn
+	return m
+}
+
+// moveTo selects index, calling the OnSelect function if it changes the
+// selection.
+func (m *Menu) moveTo(index int) {
+	if index == m.selected {
+		return
+	}
+	m.selected = index
+	if m.onSelect != nil {
+		m.onSelect(index, m.options[index])
+	}
+}
+
 // Prefix sets the prefix for unselected options.
 func (m *Menu) Prefix(prefix string) *Menu {
 	m.prefix = prefix
@@ -78,6 +117,43 @@ func (m *Menu) SelectedPrefix(prefix string) *Menu {
 	return m
 }
 
+// MaxOptionWidth truncates options wider than width at a word boundary,
+// e.g. to keep descriptions in view. Zero (the default) disables truncation.
+func (m *Menu) MaxOptionWidth(width int) *Menu {
+	m.maxOptionWidth = width
+	return m
+}
+
+// Width sets the width the menu may occupy. Descriptions that don't fit are
+// truncated, or wrapped with WrapDescriptions. Defaults to the terminal width.
+func (m *Menu) Width(w int) *Menu {
+	m.Component.Width(w)
+	return m
+}
+
+// WrapDescriptions wraps descriptions that don't fit onto further lines,
+// aligned with the description column, instead of truncating them with "…".
+func (m *Menu) WrapDescriptions(enabled bool) *Menu {
+	m.wrapDesc = enabled
+	return m
+}
+
+// Matcher sets how SelectByOption compares options, e.g. core.FoldMatch.
+// Nil (the default) requires an exact match.
+func (m *Menu) Matcher(match core.Matcher) *Menu {
+	m.matcher = match
+	return m
+}
+
+// CaseInsensitive makes SelectByOption ignore case and extra whitespace, for
+// selections read from config files or arguments.
+func (m *Menu) CaseInsensitive(enabled bool) *Menu {
+	if enabled {
+		return m.Matcher(core.FoldMatch)
+	}
+	return m.Matcher(nil)
+}
+
 // TitleStyle sets the title color.
 func (m *Menu) TitleStyle(color *style.Color) *Menu {
 	m.titleStyle = color
@@ -102,6 +178,34 @@ func (m *Menu) DescStyle(color *style.Color) *Menu {
 	return m
 }
 
+// RenderPlain renders the menu as plain text without any ANSI sequences.
+func (m *Menu) RenderPlain() string {
+	return core.RenderPlain(m)
+}
+
+// Measure returns the width and height, in cells and lines, that the menu
+// occupies when rendered with theme.
+func (m *Menu) Measure(theme *style.Theme) (width, height int) {
+	return core.Measure(m, theme)
+}
+
+// RenderContext renders the menu under ctx. Prefixes left at their defaults
+// use the Selected and Unselected glyphs of ctx.Symbols, and without a width
+// of its own the menu fits descriptions to ctx.Width instead of the terminal.
+func (m *Menu) RenderContext(ctx core.RenderContext) string {
+	menu := m.Clone()
+	if ctx.Symbols.Selected != "" && m.selectedPrefix == defaultSelectedPrefix {
+		menu.selectedPrefix = ctx.Symbols.Selected + " "
+	}
+	if ctx.Symbols.Unselected != "" && m.prefix == defaultPrefix {
+		menu.prefix = ctx.Symbols.Unselected + " "
+	}
+	if ctx.Width > 0 && m.GetWidth() <= 0 {
+		menu.Component.Width(ctx.Width)
+	}
+	return menu.Render(ctx.Theme)
+}
+
 // Render renders the menu using the given theme.
 func (m *Menu) Render(theme *style.Theme) string {
 	if m.IsHidden() || len(m.options) == 0 {
@@ -110,22 +214,22 @@ func (m *Menu) Render(theme *style.Theme) string {
 
 	titleColor := m.titleStyle
 	if titleColor == nil {
-		titleColor = theme.Header
+		titleColor = style.ColorOr(theme.Header, style.Header)
 	}
 
 	optionColor := m.optionStyle
 	if optionColor == nil {
-		optionColor = theme.Primary
+		optionColor = style.ColorOr(theme.Primary, style.Primary)
 	}
 
 	selectedColor := m.selectedStyle
 	if selectedColor == nil {
-		selectedColor = theme.Selected
+		selectedColor = style.ColorOr(theme.Selected, style.Selected)
 	}
 
 	descColor := m.descStyle
 	if descColor == nil {
-		descColor = theme.Muted
+		descColor = style.ColorOr(theme.Muted, style.Muted)
 	}
 
 	var result []string
@@ -136,45 +240,62 @@ func (m *Menu) Render(theme *style.Theme) string {
 		result = append(result, "") // Empty line
 	}
 
+	options := m.options
+	if m.maxOptionWidth > 0 {
+		renderer := core.NewRenderer(m.maxOptionWidth, 0)
+		options = make([]string, len(m.options))
+		for i, option := range m.options {
+			options[i] = renderer.TruncateWords(option, m.maxOptionWidth)
+		}
+	}
+
 	// Calculate widths for alignment
 	maxOptionWidth := 0
-	for _, option := range m.options {
-		width := runewidth.StringWidth(option)
+	for _, option := range options {
+		width := core.StringWidth(option)
 		if width > maxOptionWidth {
 			maxOptionWidth = width
 		}
 	}
 
+	width := m.GetWidth()
+	if width <= 0 {
+		width, _ = core.GetTerminalSize()
+	}
+	renderer := core.NewRenderer(width, 0)
+
 	// Add options
-	for i, option := range m.options {
-		var line string
+	for i, option := range options {
+		prefix, color := m.prefix, optionColor
+		if i == m.selected {
+			prefix, color = m.selectedPrefix, selectedColor
+		}
+		line := color.Sprint(prefix + option)
+
 		var desc string
-		
 		if i < len(m.descriptions) {
 			desc = m.descriptions[i]
 		}
 
-		if i == m.selected {
-			// Selected option
-			line = selectedColor.Sprint(m.selectedPrefix + option)
-			if desc != "" {
-				// Pad option to align descriptions
-				optionPadding := maxOptionWidth - runewidth.StringWidth(option)
-				line += strings.Repeat(" ", optionPadding + 2) // 2 extra spaces
-				line += descColor.Sprint(desc)
-			}
-		} else {
-			// Regular option
-			line = optionColor.Sprint(m.prefix + option)
-			if desc != "" {
-				// Pad option to align descriptions
-				optionPadding := maxOptionWidth - runewidth.StringWidth(option)
-				line += strings.Repeat(" ", optionPadding + 2) // 2 extra spaces
-				line += descColor.Sprint(desc)
-			}
+		// Descriptions line up in a column 2 cells after the widest option
+		// and get the rest of the width
+		column := core.StringWidth(prefix) + maxOptionWidth + 2
+		available := width - column
+		if desc == "" || available <= 0 {
+			result = append(result, line)
+			continue
+		}
+
+		descLines := []string{renderer.TruncateText(desc, available)}
+		if m.wrapDesc {
+			descLines = renderer.WrapText(desc, available)
 		}
 
+		line += strings.Repeat(" ", column-core.StringWidth(prefix+option)) + descColor.Sprint(descLines[0])
 		result = append(result, line)
+		for _, more := range descLines[1:] {
+			result = append(result, strings.Repeat(" ", column)+descColor.Sprint(more))
+		}
 	}
 
 	return strings.Join(result, "\n")
@@ -196,7 +317,7 @@ func (m *Menu) GetSelectedOption() string {
 // SelectNext moves selection to the next option.
 func (m *Menu) SelectNext() *Menu {
 	if len(m.options) > 0 {
-		m.selected = (m.selected + 1) % len(m.options)
+		m.moveTo((m.selected + 1) % len(m.options))
 	}
 	return m
 }
@@ -204,7 +325,7 @@ func (m *Menu) SelectNext() *Menu {
 // SelectPrev moves selection to the previous option.
 func (m *Menu) SelectPrev() *Menu {
 	if len(m.options) > 0 {
-		m.selected = (m.selected - 1 + len(m.options)) % len(m.options)
+		m.moveTo((m.selected - 1 + len(m.options)) % len(m.options))
 	}
 	return m
 }
@@ -212,18 +333,16 @@ func (m *Menu) SelectPrev() *Menu {
 // SelectByIndex sets the selected option by index.
 func (m *Menu) SelectByIndex(index int) *Menu {
 	if index >= 0 && index < len(m.options) {
-		m.selected = index
+		m.moveTo(index)
 	}
 	return m
 }
 
-// SelectByOption sets the selected option by matching the option text.
+// SelectByOption sets the selected option by matching the option text with
+// the menu's matcher.
 func (m *Menu) SelectByOption(option string) *Menu {
-	for i, opt := range m.options {
-		if opt == option {
-			m.selected = i
-			break
-		}
+	if i := core.FindOption(m.options, option, m.matcher); i >= 0 {
+		m.moveTo(i)
 	}
 	return m
 }
\ No newline at end of file
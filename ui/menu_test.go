@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestMenuLongDescriptions(t *testing.T) {
+	menu := NewMenu().
+		OptionsWithDesc(map[string]string{"Deploy": "Build the project, run the test suite and push the release to production"}).
+		Width(40)
+
+	lines := strings.Split(menu.RenderPlain(), "\n")
+	if len(lines) != 1 || core.MeasureText(lines[0]) != 40 || !strings.HasSuffix(lines[0], "…") {
+		t.Errorf("expected one line truncated to 40 cells, got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	lines = strings.Split(menu.WrapDescriptions(true).RenderPlain(), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected the description to wrap, got:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if core.MeasureText(line) > 40 {
+			t.Errorf("line exceeds the menu width: %q", line)
+		}
+	}
+	if !strings.HasPrefix(lines[1], strings.Repeat(" ", 10)+"test suite") {
+		t.Errorf("continuation not aligned with the description column: %q", lines[1])
+	}
+	if got := core.StripANSI(NewMenu().Options("Open").Width(40).Render(style.DefaultTheme())); got != "▶ Open" {
+		t.Errorf("options without descriptions changed: %q", got)
+	}
+}
+
+func TestMenuSelectByOptionCaseInsensitive(t *testing.T) {
+	menu := NewMenu().Options("Start", "Stop Server")
+	if menu.SelectByOption("stop server").GetSelected() != 0 {
+		t.Error("expected exact matching by default")
+	}
+	if menu.CaseInsensitive(true).SelectByOption("  STOP  server").GetSelected() != 1 {
+		t.Error("expected case-insensitive match to select the second option")
+	}
+}
+
+func TestMenuOnSelect(t *testing.T) {
+	var moves []string
+	menu := NewMenu().Options("Build", "Test", "Deploy").
+		OnSelect(func(index int, option string) {
+			moves = append(moves, fmt.Sprintf("%d:%s", index, option))
+		})
+
+	menu.SelectNext().SelectNext().SelectNext().SelectPrev()
+	menu.SelectByIndex(2).SelectByOption("test").Selected(1)
+
+	want := "[1:Test 2:Deploy 0:Build 2:Deploy 1:Test]"
+	if got := fmt.Sprint(moves); got != want {
+		t.Errorf("OnSelect calls = %s, want %s", got, want)
+	}
+}
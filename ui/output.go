@@ -0,0 +1,27 @@
+// Package ui provides the output sink interactive components draw on.
+package ui
+
+import (
+	"io"
+	"os"
+)
+
+// output replaces os.Stdout as the destination of interactive components;
+// see SetOutput.
+var output io.Writer
+
+// SetOutput sets where the interactive table selections, Table.RunSelect and
+// Table.RunMultiSelect, draw, os.Stdout by default. Answers are still read
+// from stdin. A nil writer restores os.Stdout. Rendering with Render is not
+// affected; its caller decides where the output goes.
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// out returns the writer interactive components draw on.
+func out() io.Writer {
+	if output != nil {
+		return output
+	}
+	return os.Stdout
+}
@@ -0,0 +1,112 @@
+// Package ui provides horizontal rule components.
+package ui
+
+import (
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// Rule is a horizontal line separating sections of output, optionally with a
+// centered label:
+//
+//	──────────────── Results ────────────────
+type Rule struct {
+	*core.Component
+	label string
+	char  string
+}
+
+// NewRule creates a rule spanning the terminal width. The label parts, if
+// any, are joined with spaces and centered on the line.
+func NewRule(label ...string) *Rule {
+	return &Rule{
+		Component: core.NewComponent(),
+		label:     strings.Join(label, " "),
+	}
+}
+
+// Clone returns an independent copy of the rule.
+func (r *Rule) Clone() *Rule {
+	clone := *r
+	clone.Component = r.Component.Clone()
+	return &clone
+}
+
+// Label sets the text centered on the rule. An empty label draws a plain
+// line. A label too wide for the rule is truncated with "…".
+func (r *Rule) Label(label string) *Rule {
+	r.label = label
+	return r
+}
+
+// Char sets the glyph the line is drawn with. It should be one cell wide. By
+// default it is the box drawing "─", or "-" without Unicode support.
+func (r *Rule) Char(char string) *Rule {
+	r.char = char
+	return r
+}
+
+// Width sets the rule width. By default it spans the terminal.
+func (r *Rule) Width(w int) *Rule {
+	r.Component.Width(w)
+	return r
+}
+
+// Render renders the rule using the given theme.
+func (r *Rule) Render(theme *style.Theme) string {
+	return r.RenderContext(core.NewRenderContext(theme))
+}
+
+// RenderContext renders the rule under ctx, spanning ctx.Width when the rule
+// has no width of its own and drawing with ctx.Symbols.BoxHorizontal.
+func (r *Rule) RenderContext(ctx core.RenderContext) string {
+	if r.IsHidden() {
+		return ""
+	}
+	theme := ctx.Theme
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+
+	width := r.GetWidth()
+	if width <= 0 {
+		width = ctx.Width
+	}
+	if width <= 0 {
+		width, _ = core.GetTerminalSize()
+	}
+
+	char := r.glyph(ctx.Symbols)
+	lineColor := style.ColorOr(theme.Border, style.Border)
+	if r.label == "" {
+		return lineColor.Sprint(strings.Repeat(char, width))
+	}
+
+	// At least one glyph and a space on each side of the label; a label too
+	// wide for that is truncated, and dropped when not even "…" fits
+	if width < 5 {
+		return lineColor.Sprint(strings.Repeat(char, width))
+	}
+	label := core.TruncateCells(r.label, width-4)
+	sides := width - core.StringWidth(label) - 2
+	left := sides / 2
+	return lineColor.Sprint(strings.Repeat(char, left)) + " " +
+		style.ColorOr(theme.Header, style.Header).Sprint(label) + " " +
+		lineColor.Sprint(strings.Repeat(char, sides-left))
+}
+
+// glyph returns the character the line is drawn with.
+func (r *Rule) glyph(symbols style.SymbolSet) string {
+	switch {
+	case r.char != "":
+		return r.char
+	case symbols.BoxHorizontal != "" && symbols.BoxHorizontal != style.BoxHorizontal:
+		return symbols.BoxHorizontal
+	case !style.SupportsUnicode():
+		return style.ClassicBoxHorizontal
+	default:
+		return style.BoxHorizontal
+	}
+}
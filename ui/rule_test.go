@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestRule(t *testing.T) {
+	previous := style.SupportsUnicode()
+	style.SetUnicodeSupport(true)
+	defer style.SetUnicodeSupport(previous)
+	theme := style.DefaultTheme()
+
+	tests := []struct {
+		name     string
+		rule     *Rule
+		expected string
+	}{
+		{"plain", NewRule().Width(6), "──────"},
+		{"label", NewRule("Section").Width(17), "──── Section ────"},
+		{"joined label", NewRule("Step", "2").Width(12), "── Step 2 ──"},
+		{"uneven", NewRule("ab").Width(9), "── ab ───"},
+		{"truncated", NewRule("Section").Width(9), "─ Sect… ─"},
+		{"wide label", NewRule("部署状态").Width(9), "─ 部署… ─"},
+		{"too narrow", NewRule("Section").Width(4), "────"},
+		{"custom char", NewRule().Char("=").Width(3), "==="},
+	}
+	for _, tt := range tests {
+		if got := core.StripANSI(tt.rule.Render(theme)); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+
+	ctx := core.NewRenderContext(theme)
+	ctx.Width = 5
+	ctx.Symbols = style.ASCIISymbols()
+	if got := core.StripANSI(NewRule().RenderContext(ctx)); got != "-----" {
+		t.Errorf("expected the context width and symbols, got %q", got)
+	}
+
+	if got := core.StripANSI(NewRule("x").Width(5).Render(nil)); got != "─ x ─" {
+		t.Errorf("a nil theme should use the default one, got %q", got)
+	}
+
+	style.SetUnicodeSupport(false)
+	if got := core.StripANSI(NewRule().Width(4).Render(theme)); got != "----" {
+		t.Errorf("expected ASCII dashes without Unicode support, got %q", got)
+	}
+}
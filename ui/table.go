@@ -3,11 +3,11 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
-	"github.com/mattn/go-runewidth"
 )
 
 // Table represents a data table component.
@@ -16,32 +16,149 @@ type Table struct {
 	headers     []string
 	rows        [][]string
 	columnWidths []int
+	columnMax   []int
+	formatters  map[int]func(string) string
+	minWidth    int
+	maxWidth    int
 	border      bool
+	showHeader  bool
 	borderStyle *style.Color
 	headerStyle *style.Color
 	rowStyle    *style.Color
 	altRowStyle *style.Color
 	alignment   []core.Alignment
+	explicitAlign int
+	autoAlign   bool
+	details     bool
+	striped     bool
+	cellPadding int
+	rtlColumns  map[int]bool
+	highlight   int // Row drawn in the selected color, or -1
+	frozen      int
+	scroll      int
+	sanitize    bool
+	glyphs      tableGlyphs
+
+	// AppendAndRedraw state: the theme, the lines last drawn and their layout
+	streamTheme  *style.Theme
+	streamed     int
+	streamLayout tableLayout
+}
+
+// TableStyle bundles a table's colors so they can be defined once and applied
+// to many tables with ApplyStyle. Nil colors fall back to the theme.
+type TableStyle struct {
+	Border *style.Color
+	Header *style.Color
+	Row    *style.Color
+	AltRow *style.Color
+}
+
+// tableGlyphs are the strings a bordered table is drawn with.
+type tableGlyphs struct {
+	topLeft, topRight, bottomLeft, bottomRight string
+	horizontal, vertical                       string
+	teeLeft, teeRight, teeTop, teeBottom       string
+	cross                                      string
+}
+
+// defaultTableGlyphs returns the rounded box drawing glyphs tables use by
+// default.
+func defaultTableGlyphs() tableGlyphs {
+	return tableGlyphs{
+		topLeft:     style.BoxTopLeft,
+		topRight:    style.BoxTopRight,
+		bottomLeft:  style.BoxBottomLeft,
+		bottomRight: style.BoxBottomRight,
+		horizontal:  style.BoxHorizontal,
+		vertical:    style.BoxVertical,
+		teeLeft:     style.BoxTee,
+		teeRight:    style.BoxTeeRight,
+		teeTop:      style.BoxTeeTop,
+		teeBottom:   style.BoxTeeBottom,
+		cross:       style.BoxCross,
+	}
+}
+
+// symbolTableGlyphs returns the table glyphs of a symbol set. Symbol sets
+// have no glyphs for the tees on the top and bottom border, so those are
+// drawn with BoxCross, or the horizontal glyph when the set has none.
+func symbolTableGlyphs(symbols style.SymbolSet) tableGlyphs {
+	chars := core.SymbolBoxChars(symbols)
+	cross := symbols.BoxCross
+	if cross == "" {
+		cross = string(chars.Horizontal)
+	}
+	return tableGlyphs{
+		topLeft:     string(chars.TopLeft),
+		topRight:    string(chars.TopRight),
+		bottomLeft:  string(chars.BottomLeft),
+		bottomRight: string(chars.BottomRight),
+		horizontal:  string(chars.Horizontal),
+		vertical:    string(chars.Vertical),
+		teeLeft:     string(chars.LeftTee),
+		teeRight:    string(chars.RightTee),
+		teeTop:      cross,
+		teeBottom:   cross,
+		cross:       cross,
+	}
+}
+
+// tableLayout holds the per-column geometry resolved for a single render.
+type tableLayout struct {
+	widths []int
+	aligns []core.Alignment
 }
 
 // NewTable creates a new table component.
 func NewTable() *Table {
 	return &Table{
-		Component: core.NewComponent(),
-		border:    true,
-		alignment: []core.Alignment{core.AlignLeft}, // Default alignment
+		Component:   core.NewComponent(),
+		border:      true,
+		showHeader:  true,
+		cellPadding: 1,
+		highlight:   -1,
+		glyphs:      defaultTableGlyphs(),
+		alignment:   []core.Alignment{core.AlignLeft}, // Default alignment
+	}
+}
+
+// Clone returns an independent copy of the table, including its rows.
+func (t *Table) Clone() *Table {
+	clone := *t
+	clone.Component = t.Component.Clone()
+	clone.headers = append([]string(nil), t.headers...)
+	clone.rows = make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		clone.rows[i] = append([]string(nil), row...)
 	}
+	clone.columnWidths = append([]int(nil), t.columnWidths...)
+	clone.columnMax = append([]int(nil), t.columnMax...)
+	if t.rtlColumns != nil {
+		clone.rtlColumns = make(map[int]bool, len(t.rtlColumns))
+		for col := range t.rtlColumns {
+			clone.rtlColumns[col] = true
+		}
+	}
+	clone.alignment = append([]core.Alignment(nil), t.alignment...)
+	if t.formatters != nil {
+		clone.formatters = make(map[int]func(string) string, len(t.formatters))
+		for col, format := range t.formatters {
+			clone.formatters[col] = format
+		}
+	}
+	return &clone
 }
 
-// Headers sets the table headers.
+// Headers sets the table headers. Column widths are recomputed to fit the
+// headers and any rows already added.
 func (t *Table) Headers(headers ...string) *Table {
 	t.headers = headers
-	// Auto-initialize column widths and alignment if not set
-	if len(t.columnWidths) == 0 {
-		t.columnWidths = make([]int, len(headers))
-		for i, header := range headers {
-			t.columnWidths[i] = runewidth.StringWidth(header)
-		}
+	// Auto-initialize column widths and alignment if not set; rows added
+	// before the headers sized the columns without them
+	if len(t.columnWidths) == 0 || len(t.rows) > 0 {
+		t.columnWidths = nil
+		t.calculateColumnWidths()
 	}
 	if len(t.alignment) < len(headers) {
 		for len(t.alignment) < len(headers) {
@@ -71,6 +188,89 @@ func (t *Table) ColumnWidths(widths ...int) *Table {
 	return t
 }
 
+// ColumnFormatter sets a function that formats the data cells of column col
+// for display, e.g. NumberFormatter(",", ".") or DateFormatter. Rows keep
+// their raw values; column widths are measured on the formatted values.
+func (t *Table) ColumnFormatter(col int, format func(string) string) *Table {
+	if t.formatters == nil {
+		t.formatters = make(map[int]func(string) string)
+	}
+	t.formatters[col] = format
+	t.calculateColumnWidths()
+	return t
+}
+
+// SanitizeCells removes ANSI escape sequences and control characters from
+// data cells before they are measured and rendered, for tables showing
+// untrusted data such as log lines or user input, which could otherwise
+// inject colors, move the cursor or break the layout. Tabs and line breaks
+// become spaces. The table's own colors and column formatters still apply;
+// formatters receive the sanitized value.
+func (t *Table) SanitizeCells(enabled bool) *Table {
+	t.sanitize = enabled
+	t.calculateColumnWidths()
+	return t
+}
+
+// ColumnMaxWidths caps the width of each column; cells that do not fit are
+// truncated. A zero or missing entry leaves the column uncapped.
+func (t *Table) ColumnMaxWidths(widths ...int) *Table {
+	t.columnMax = widths
+	return t
+}
+
+// TruncateWithDetails marks truncated data cells with a reference number such
+// as "[1]" and lists their full values in a detail section below the table,
+// so capping column widths keeps the grid compact without losing data.
+func (t *Table) TruncateWithDetails(enabled bool) *Table {
+	t.details = enabled
+	return t
+}
+
+// MinWidth sets the minimum total width of the table. The last column is
+// widened to reach it.
+func (t *Table) MinWidth(w int) *Table {
+	t.minWidth = w
+	return t
+}
+
+// MaxWidth sets the maximum total width of the table. The widest columns are
+// narrowed (and their cells truncated) until the table fits.
+func (t *Table) MaxWidth(w int) *Table {
+	t.maxWidth = w
+	return t
+}
+
+// FreezeColumns keeps the first n columns, e.g. row labels, in view while
+// the others scroll horizontally with ScrollColumns, like a spreadsheet
+// freeze pane. With MaxWidth set (say to the terminal width), only as many
+// of the scrolling columns as fit are drawn, instead of narrowing them all.
+func (t *Table) FreezeColumns(n int) *Table {
+	if n < 0 {
+		n = 0
+	}
+	t.frozen = n
+	return t
+}
+
+// ScrollColumns sets the horizontal scroll position: the number of columns
+// after the frozen ones that are scrolled out of view. It is clamped so at
+// least one scrolling column stays visible.
+func (t *Table) ScrollColumns(offset int) *Table {
+	if offset < 0 {
+		offset = 0
+	}
+	t.scroll = offset
+	return t
+}
+
+// ShowHeader controls whether the header row is displayed. Headers still
+// contribute to column widths when hidden.
+func (t *Table) ShowHeader(show bool) *Table {
+	t.showHeader = show
+	return t
+}
+
 // Border enables or disables table borders.
 func (t *Table) Border(enabled bool) *Table {
 	t.border = enabled
@@ -95,97 +295,341 @@ func (t *Table) RowStyle(color *style.Color) *Table {
 	return t
 }
 
-// AltRowStyle sets the alternating row color.
+// AltRowStyle sets the color of every other row when the table is Striped.
 func (t *Table) AltRowStyle(color *style.Color) *Table {
 	t.altRowStyle = color
 	return t
 }
 
-// Alignment sets column alignments.
+// CellPadding sets the number of spaces between a cell and the vertical
+// borders on each side (default 1). It applies to bordered tables.
+func (t *Table) CellPadding(n int) *Table {
+	if n < 0 {
+		n = 0
+	}
+	t.cellPadding = n
+	return t
+}
+
+// Striped enables alternating row colors (RowStyle, AltRowStyle). By default
+// every row uses RowStyle.
+func (t *Table) Striped(striped bool) *Table {
+	t.striped = striped
+	return t
+}
+
+// ApplyStyle sets all color slots of the table from s.
+func (t *Table) ApplyStyle(s TableStyle) *Table {
+	t.borderStyle = s.Border
+	t.headerStyle = s.Header
+	t.rowStyle = s.Row
+	t.altRowStyle = s.AltRow
+	return t
+}
+
+// Alignment sets column alignments. Explicit alignments take precedence over
+// AutoAlign.
 func (t *Table) Alignment(alignments ...core.Alignment) *Table {
 	t.alignment = alignments
+	t.explicitAlign = len(alignments)
 	return t
 }
 
-// Render renders the table using the given theme.
+// RTL marks columns holding right-to-left text such as Arabic or Hebrew. Their
+// alignment is mirrored, so left-aligned cells hug the right edge.
+func (t *Table) RTL(cols ...int) *Table {
+	if t.rtlColumns == nil {
+		t.rtlColumns = make(map[int]bool)
+	}
+	for _, col := range cols {
+		t.rtlColumns[col] = true
+	}
+	return t
+}
+
+// AutoAlign right-aligns columns whose non-empty cells are all numbers,
+// leaving text columns left-aligned. Columns with an explicit Alignment are
+// not affected.
+func (t *Table) AutoAlign(enabled bool) *Table {
+	t.autoAlign = enabled
+	return t
+}
+
+// RenderPlain renders the table as plain text without any ANSI sequences.
+func (t *Table) RenderPlain() string {
+	return core.RenderPlain(t)
+}
+
+// Measure returns the width and height, in cells and lines, that the table
+// occupies when rendered with theme.
+func (t *Table) Measure(theme *style.Theme) (width, height int) {
+	return core.Measure(t, theme)
+}
+
+// RenderContext renders the table under ctx. A symbol set whose box glyphs
+// differ from the default rounded ones, such as style.ASCIISymbols, replaces
+// the border glyphs, and without a MaxWidth of its own the table fits within
+// ctx.Width.
+func (t *Table) RenderContext(ctx core.RenderContext) string {
+	table := *t
+	if ctx.Symbols.BoxTopLeft != "" && ctx.Symbols.BoxTopLeft != style.BoxTopLeft {
+		table.glyphs = symbolTableGlyphs(ctx.Symbols)
+	}
+	if ctx.Width > 0 && t.maxWidth <= 0 {
+		table.maxWidth = ctx.Width
+	}
+	return table.Render(ctx.Theme)
+}
+
+// Render renders the table using the given theme. Headers are optional; a
+// table without headers takes its column count and widths from the rows.
 func (t *Table) Render(theme *style.Theme) string {
-	if t.IsHidden() || len(t.headers) == 0 {
+	if t.IsHidden() || (len(t.headers) == 0 && len(t.rows) == 0) {
 		return ""
 	}
+	if t.frozen > 0 || t.scroll > 0 {
+		return t.window().Render(theme)
+	}
 
 	borderColor := t.borderStyle
 	if borderColor == nil {
-		borderColor = theme.Border
+		borderColor = style.ColorOr(theme.Border, style.Border)
 	}
 
 	headerColor := t.headerStyle
 	if headerColor == nil {
-		headerColor = theme.Header
+		headerColor = style.ColorOr(theme.Header, style.Header)
 	}
 
 	rowColor := t.rowStyle
 	if rowColor == nil {
-		rowColor = theme.Primary
+		rowColor = style.ColorOr(theme.Primary, style.Primary)
 	}
 
-	altRowColor := t.altRowStyle
-	if altRowColor == nil {
-		altRowColor = theme.Secondary
+	layout := t.layout()
+	widths := layout.widths
+	showHeader := t.showHeader && len(t.headers) > 0
+
+	rows := t.displayRows()
+	var details []string
+	if t.details {
+		rows, details = t.detailRows(rows, widths)
 	}
 
 	var result []string
 
 	if t.border {
 		// Top border
-		result = append(result, t.renderTopBorder(borderColor))
-		
-		// Header row
-		result = append(result, t.renderRow(t.headers, headerColor, borderColor, true))
+		result = append(result, t.renderTopBorder(widths, borderColor))
 		
-		// Header separator
-		result = append(result, t.renderSeparator(borderColor))
+		if showHeader {
+			// Header row
+			result = append(result, t.renderRow(layout, t.headers, headerColor, borderColor, true))
+
+			// Header separator
+			result = append(result, t.renderSeparator(widths, borderColor))
+		}
 		
 		// Data rows
-		for i, row := range t.rows {
-			var color *style.Color
-			if i%2 == 0 {
-				color = rowColor
-			} else {
-				color = altRowColor
-			}
-			result = append(result, t.renderRow(row, color, borderColor, false))
+		for i, row := range rows {
+			color := t.dataRowColor(theme, i)
+			result = append(result, t.renderRow(layout, row, color, borderColor, false))
 		}
 		
 		// Bottom border
-		result = append(result, t.renderBottomBorder(borderColor))
+		result = append(result, t.renderBottomBorder(widths, borderColor))
 	} else {
 		// No border version
-		result = append(result, t.renderRowNoBorder(t.headers, headerColor))
-		result = append(result, strings.Repeat("-", t.getTotalWidth()))
+		if showHeader {
+			result = append(result, t.renderRowNoBorder(layout, t.headers, headerColor))
+			result = append(result, strings.Repeat("-", t.getTotalWidth(widths)))
+		}
 		
-		for i, row := range t.rows {
-			var color *style.Color
-			if i%2 == 0 {
-				color = rowColor
-			} else {
-				color = altRowColor
-			}
-			result = append(result, t.renderRowNoBorder(row, color))
+		for i, row := range rows {
+			color := t.dataRowColor(theme, i)
+			result = append(result, t.renderRowNoBorder(layout, row, color))
+		}
+	}
+
+	if len(details) > 0 {
+		detailColor := style.ColorOr(theme.Muted, style.Muted)
+		result = append(result, "")
+		for i, detail := range details {
+			result = append(result, detailColor.Sprintf("[%d] ", i+1)+rowColor.Sprint(detail))
 		}
 	}
 
 	return strings.Join(result, "\n")
 }
 
+// dataRowColor returns the color of data row i: the selected color when it
+// is highlighted, the alternate row color on odd rows of a striped table and
+// the row color otherwise.
+func (t *Table) dataRowColor(theme *style.Theme, i int) *style.Color {
+	switch {
+	case i == t.highlight:
+		return style.ColorOr(theme.Selected, style.Selected)
+	case t.striped && i%2 == 1 && t.altRowStyle != nil:
+		return t.altRowStyle
+	case t.striped && i%2 == 1:
+		return style.ColorOr(theme.Secondary, style.Secondary)
+	case t.rowStyle != nil:
+		return t.rowStyle
+	default:
+		return style.ColorOr(theme.Primary, style.Primary)
+	}
+}
+
+// window returns a copy of the table holding only the columns in view: the
+// frozen ones, then the scrolling ones from the scroll position on, as many
+// as fit within MaxWidth.
+func (t *Table) window() *Table {
+	// Lay out at natural widths; MaxWidth selects columns instead
+	natural := *t
+	natural.maxWidth = 0
+	full := natural.layout()
+	columns := len(full.widths)
+	frozen := t.frozen
+	if frozen > columns {
+		frozen = columns
+	}
+
+	visible := make([]int, 0, columns)
+	for col := 0; col < frozen; col++ {
+		visible = append(visible, col)
+	}
+	start := frozen + t.scroll
+	if start >= columns {
+		start = columns - 1
+	}
+	for col := start; col >= frozen && col < columns; col++ {
+		widths := make([]int, 0, len(visible)+1)
+		for _, v := range visible {
+			widths = append(widths, full.widths[v])
+		}
+		widths = append(widths, full.widths[col])
+		if t.maxWidth > 0 && col > start && t.renderedWidth(widths) > t.maxWidth {
+			break
+		}
+		visible = append(visible, col)
+	}
+
+	project := func(cells []string) []string {
+		projected := make([]string, 0, len(visible))
+		for _, col := range visible {
+			if col < len(cells) {
+				projected = append(projected, cells[col])
+			}
+		}
+		return projected
+	}
+
+	view := t.Clone()
+	view.frozen, view.scroll = 0, 0
+	view.headers = project(t.headers)
+	for r, row := range t.rows {
+		view.rows[r] = project(row)
+	}
+	view.columnWidths = make([]int, len(visible))
+	view.columnMax = make([]int, len(visible))
+	view.alignment = make([]core.Alignment, len(visible))
+	view.formatters = nil
+	view.rtlColumns = nil
+	for i, col := range visible {
+		if col < len(t.columnWidths) {
+			view.columnWidths[i] = t.columnWidths[col]
+		}
+		if col < len(t.columnMax) {
+			view.columnMax[i] = t.columnMax[col]
+		}
+		view.alignment[i] = full.aligns[col]
+		if format, ok := t.formatters[col]; ok {
+			if view.formatters == nil {
+				view.formatters = make(map[int]func(string) string)
+			}
+			view.formatters[i] = format
+		}
+	}
+	view.explicitAlign = len(visible)
+	view.autoAlign = false
+	return view
+}
+
+// detailRows returns the data rows with every cell too wide for its column
+// replaced by a truncated value ending in a reference number, along with the
+// full values in reference order. Cells in columns too narrow for the
+// number are only truncated.
+func (t *Table) detailRows(source [][]string, widths []int) ([][]string, []string) {
+	var details []string
+	rows := make([][]string, len(source))
+	for r, row := range source {
+		rows[r] = row
+		copied := false
+		for i, cell := range row {
+			if i >= len(widths) || core.MeasureText(cell) <= widths[i] {
+				continue
+			}
+			if !copied {
+				rows[r] = append([]string(nil), row...)
+				copied = true
+			}
+
+			// A column too narrow for the marker is truncated without a
+			// detail line, which no cell could point to
+			ref := fmt.Sprintf("[%d]", len(details)+1)
+			refWidth := core.StringWidth(ref)
+			if widths[i] <= refWidth {
+				rows[r][i] = core.TruncateCells(cell, widths[i])
+				continue
+			}
+			details = append(details, cell)
+			rows[r][i] = core.TruncateCells(cell, widths[i]-refWidth) + ref
+		}
+	}
+	return rows, details
+}
+
+// displayRows returns the data rows sanitized and with column formatters
+// applied.
+func (t *Table) displayRows() [][]string {
+	if len(t.formatters) == 0 && !t.sanitize {
+		return t.rows
+	}
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		rows[r] = t.formatRow(row)
+	}
+	return rows
+}
+
+// formatRow returns a copy of row, sanitized if SanitizeCells is set and with
+// column formatters applied.
+func (t *Table) formatRow(row []string) []string {
+	if len(t.formatters) == 0 && !t.sanitize {
+		return row
+	}
+	formatted := append([]string(nil), row...)
+	for i, cell := range formatted {
+		if t.sanitize {
+			cell = core.SanitizeText(cell)
+		}
+		if format, ok := t.formatters[i]; ok {
+			cell = format(cell)
+		}
+		formatted[i] = cell
+	}
+	return formatted
+}
+
 func (t *Table) calculateColumnWidths() {
-	if len(t.columnWidths) == 0 {
+	if len(t.columnWidths) == 0 || len(t.headers) == 0 {
 		t.columnWidths = make([]int, len(t.headers))
 	}
 
 	// Initialize with header widths
 	for i, header := range t.headers {
 		if i < len(t.columnWidths) {
-			t.columnWidths[i] = runewidth.StringWidth(header)
+			t.columnWidths[i] = core.MeasureText(header)
 		}
 	}
 
@@ -196,9 +640,16 @@ func (t *Table) calculateColumnWidths() {
 }
 
 func (t *Table) updateColumnWidthsForRow(row []string) {
-	for i, cell := range row {
+	// Without headers, the widest row defines the column count
+	if len(t.headers) == 0 {
+		for len(t.columnWidths) < len(row) {
+			t.columnWidths = append(t.columnWidths, 0)
+		}
+	}
+
+	for i, cell := range t.formatRow(row) {
 		if i < len(t.columnWidths) {
-			cellWidth := runewidth.StringWidth(cell)
+			cellWidth := core.MeasureText(cell)
 			if cellWidth > t.columnWidths[i] {
 				t.columnWidths[i] = cellWidth
 			}
@@ -206,6 +657,97 @@ func (t *Table) updateColumnWidthsForRow(row []string) {
 	}
 }
 
+// layoutWidths returns the column widths to render with, adjusted so the
+// table fits within the configured minimum and maximum width.
+func (t *Table) layoutWidths() []int {
+	widths := append([]int(nil), t.columnWidths...)
+	if len(widths) == 0 {
+		return widths
+	}
+
+	for i, limit := range t.columnMax {
+		if i < len(widths) && limit > 0 && widths[i] > limit {
+			widths[i] = limit
+		}
+	}
+
+	if t.minWidth > 0 {
+		if total := t.renderedWidth(widths); total < t.minWidth {
+			widths[len(widths)-1] += t.minWidth - total
+		}
+	}
+
+	if t.maxWidth > 0 {
+		for total := t.renderedWidth(widths); total > t.maxWidth; total-- {
+			widest := 0
+			for i, width := range widths {
+				if width > widths[widest] {
+					widest = i
+				}
+			}
+			if widths[widest] <= 1 {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	return widths
+}
+
+// renderedWidth returns the display width of a table line for the given column widths.
+func (t *Table) renderedWidth(widths []int) int {
+	if !t.border {
+		return t.getTotalWidth(widths)
+	}
+	total := len(widths) + 1 // Vertical borders
+	for _, width := range widths {
+		total += width + 2*t.cellPadding
+	}
+	return total
+}
+
+// layout resolves column widths and alignments for rendering.
+func (t *Table) layout() tableLayout {
+	widths := t.layoutWidths()
+	aligns := make([]core.Alignment, len(widths))
+	for i := range aligns {
+		aligns[i] = t.getAlignment(i)
+		if t.rtlColumns[i] {
+			aligns[i] = aligns[i].Mirrored()
+		}
+		if t.autoAlign && i >= t.explicitAlign && t.isNumericColumn(i) {
+			aligns[i] = core.AlignRight
+		}
+	}
+	return tableLayout{widths: widths, aligns: aligns}
+}
+
+// isNumericColumn reports whether every non-empty cell of the column parses as
+// a number. Thousands separators and a trailing percent sign are allowed.
+func (t *Table) isNumericColumn(colIndex int) bool {
+	found := false
+	for _, row := range t.rows {
+		if colIndex >= len(row) {
+			continue
+		}
+		cell := row[colIndex]
+		if t.sanitize {
+			cell = core.SanitizeText(cell)
+		}
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		cell = strings.TrimSuffix(strings.ReplaceAll(cell, ",", ""), "%")
+		if _, err := strconv.ParseFloat(cell, 64); err != nil {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
 func (t *Table) getAlignment(colIndex int) core.Alignment {
 	if colIndex < len(t.alignment) {
 		return t.alignment[colIndex]
@@ -213,95 +755,96 @@ func (t *Table) getAlignment(colIndex int) core.Alignment {
 	return core.AlignLeft
 }
 
-func (t *Table) renderTopBorder(borderColor *style.Color) string {
+func (t *Table) renderTopBorder(widths []int, borderColor *style.Color) string {
 	var parts []string
-	parts = append(parts, borderColor.Sprint(style.BoxTopLeft))
+	parts = append(parts, borderColor.Sprint(t.glyphs.topLeft))
 	
-	for i, width := range t.columnWidths {
+	for i, width := range widths {
 		if i > 0 {
-			parts = append(parts, borderColor.Sprint(style.BoxTeeTop))
+			parts = append(parts, borderColor.Sprint(t.glyphs.teeTop))
 		}
-		parts = append(parts, borderColor.Sprint(strings.Repeat(style.BoxHorizontal, width+2))) // +2 for padding
+		parts = append(parts, borderColor.Sprint(strings.Repeat(t.glyphs.horizontal, width+2*t.cellPadding)))
 	}
 	
-	parts = append(parts, borderColor.Sprint(style.BoxTopRight))
+	parts = append(parts, borderColor.Sprint(t.glyphs.topRight))
 	return strings.Join(parts, "")
 }
 
-func (t *Table) renderBottomBorder(borderColor *style.Color) string {
+func (t *Table) renderBottomBorder(widths []int, borderColor *style.Color) string {
 	var parts []string
-	parts = append(parts, borderColor.Sprint(style.BoxBottomLeft))
+	parts = append(parts, borderColor.Sprint(t.glyphs.bottomLeft))
 	
-	for i, width := range t.columnWidths {
+	for i, width := range widths {
 		if i > 0 {
-			parts = append(parts, borderColor.Sprint(style.BoxTeeBottom))
+			parts = append(parts, borderColor.Sprint(t.glyphs.teeBottom))
 		}
-		parts = append(parts, borderColor.Sprint(strings.Repeat(style.BoxHorizontal, width+2))) // +2 for padding
+		parts = append(parts, borderColor.Sprint(strings.Repeat(t.glyphs.horizontal, width+2*t.cellPadding)))
 	}
 	
-	parts = append(parts, borderColor.Sprint(style.BoxBottomRight))
+	parts = append(parts, borderColor.Sprint(t.glyphs.bottomRight))
 	return strings.Join(parts, "")
 }
 
-func (t *Table) renderSeparator(borderColor *style.Color) string {
+func (t *Table) renderSeparator(widths []int, borderColor *style.Color) string {
 	var parts []string
-	parts = append(parts, borderColor.Sprint(style.BoxTee))
+	parts = append(parts, borderColor.Sprint(t.glyphs.teeLeft))
 	
-	for i, width := range t.columnWidths {
+	for i, width := range widths {
 		if i > 0 {
-			parts = append(parts, borderColor.Sprint(style.BoxCross))
+			parts = append(parts, borderColor.Sprint(t.glyphs.cross))
 		}
-		parts = append(parts, borderColor.Sprint(strings.Repeat(style.BoxHorizontal, width+2))) // +2 for padding
+		parts = append(parts, borderColor.Sprint(strings.Repeat(t.glyphs.horizontal, width+2*t.cellPadding)))
 	}
 	
-	parts = append(parts, borderColor.Sprint(style.BoxTeeRight))
+	parts = append(parts, borderColor.Sprint(t.glyphs.teeRight))
 	return strings.Join(parts, "")
 }
 
-func (t *Table) renderRow(cells []string, cellColor, borderColor *style.Color, isHeader bool) string {
+func (t *Table) renderRow(layout tableLayout, cells []string, cellColor, borderColor *style.Color, isHeader bool) string {
 	var parts []string
-	parts = append(parts, borderColor.Sprint(style.BoxVertical))
+	parts = append(parts, borderColor.Sprint(t.glyphs.vertical))
+	padding := strings.Repeat(" ", t.cellPadding)
 	
-	for i, width := range t.columnWidths {
+	for i, width := range layout.widths {
 		var cell string
 		if i < len(cells) {
 			cell = cells[i]
 		}
 		
 		// Truncate if too long
-		if runewidth.StringWidth(cell) > width {
-			cell = runewidth.Truncate(cell, width, "…")
+		if core.MeasureText(cell) > width {
+			cell = core.TruncateCells(cell, width)
 		}
 		
 		// Apply alignment
-		alignment := t.getAlignment(i)
+		alignment := layout.aligns[i]
 		renderer := core.NewRenderer(width, 1)
 		paddedCell := renderer.PadText(cell, width, alignment)
 		
 		styledCell := cellColor.Sprint(paddedCell)
-		parts = append(parts, fmt.Sprintf(" %s ", styledCell))
-		parts = append(parts, borderColor.Sprint(style.BoxVertical))
+		parts = append(parts, padding+styledCell+padding)
+		parts = append(parts, borderColor.Sprint(t.glyphs.vertical))
 	}
 	
 	return strings.Join(parts, "")
 }
 
-func (t *Table) renderRowNoBorder(cells []string, cellColor *style.Color) string {
+func (t *Table) renderRowNoBorder(layout tableLayout, cells []string, cellColor *style.Color) string {
 	var parts []string
 	
-	for i, width := range t.columnWidths {
+	for i, width := range layout.widths {
 		var cell string
 		if i < len(cells) {
 			cell = cells[i]
 		}
 		
 		// Truncate if too long
-		if runewidth.StringWidth(cell) > width {
-			cell = runewidth.Truncate(cell, width, "…")
+		if core.MeasureText(cell) > width {
+			cell = core.TruncateCells(cell, width)
 		}
 		
 		// Apply alignment
-		alignment := t.getAlignment(i)
+		alignment := layout.aligns[i]
 		renderer := core.NewRenderer(width, 1)
 		paddedCell := renderer.PadText(cell, width, alignment)
 		
@@ -312,14 +855,14 @@ func (t *Table) renderRowNoBorder(cells []string, cellColor *style.Color) string
 	return strings.Join(parts, " ")
 }
 
-func (t *Table) getTotalWidth() int {
+func (t *Table) getTotalWidth(widths []int) int {
 	total := 0
-	for _, width := range t.columnWidths {
+	for _, width := range widths {
 		total += width
 	}
 	// Add separators
-	if len(t.columnWidths) > 1 {
-		total += len(t.columnWidths) - 1
+	if len(widths) > 1 {
+		total += len(widths) - 1
 	}
 	return total
 }
\ No newline at end of file
@@ -0,0 +1,265 @@
+// Package ui provides interactive table row selection.
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// errNoRows is returned when selecting from a table without rows.
+var errNoRows = errors.New("no rows to select")
+
+// RunSelect shows the table as a row picker: the arrow keys (or j/k) move a
+// highlight drawn in the theme's selected color, Enter returns the index of
+// the highlighted row, and Ctrl-C or Esc returns core.ErrCancelled. When stdin
+// is not a terminal the table is printed and a row number is read instead.
+// A nil theme uses style.DefaultTheme.
+func (t *Table) RunSelect(theme *style.Theme) (int, error) {
+	if len(t.rows) == 0 {
+		return -1, errNoRows
+	}
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		return t.selectByNumber(theme)
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return -1, err
+	}
+	defer guard.Release()
+
+	keys := core.NewKeyReader(core.Stdin())
+	screen := &core.LiveRegion{Writer: out()}
+	view := t.Clone()
+	cursor := 0
+	hint := style.ColorOr(theme.Muted, style.Muted).Sprint("↑/↓ move · enter select · esc cancel")
+
+	for {
+		view.highlight = cursor
+		screen.Draw(append(strings.Split(view.Render(theme), "\n"), hint))
+
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			return -1, core.ErrAborted
+		}
+		if err != nil {
+			return -1, err
+		}
+
+		switch {
+		case key.Type == core.KeyUp || key.Type == core.KeyRune && key.Rune == 'k':
+			cursor = (cursor - 1 + len(t.rows)) % len(t.rows)
+		case key.Type == core.KeyDown || key.Type == core.KeyRune && key.Rune == 'j':
+			cursor = (cursor + 1) % len(t.rows)
+		case key.Type == core.KeyHome:
+			cursor = 0
+		case key.Type == core.KeyEnd:
+			cursor = len(t.rows) - 1
+		case key.Type == core.KeyEnter:
+			return cursor, nil
+		case key.Type == core.KeyCtrlC || key.Type == core.KeyEscape:
+			return -1, core.ErrCancelled
+		case key.Type == core.KeyCtrlD:
+			return -1, core.ErrAborted
+		}
+	}
+}
+
+// selectByNumber prints the table and reads a 1-based row number, asking
+// again until the answer is in range.
+func (t *Table) selectByNumber(theme *style.Theme) (int, error) {
+	fmt.Fprintln(out(), t.Render(theme))
+
+	reader := core.Stdin()
+	for {
+		fmt.Fprint(out(), style.ColorOr(theme.Primary, style.Primary).Sprintf("Select a row [1-%d]: ", len(t.rows)))
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return -1, core.ErrAborted
+			}
+			return -1, err
+		}
+
+		if choice, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && choice >= 1 && choice <= len(t.rows) {
+			return choice - 1, nil
+		}
+		fmt.Fprintln(out(), style.ColorOr(theme.Error, style.Error).Sprintf("Please enter a number between 1 and %d", len(t.rows)))
+		if err == io.EOF {
+			return -1, core.ErrAborted
+		}
+	}
+}
+
+// RunMultiSelect shows the table with a leading checkbox column: the arrow
+// keys (or j/k) move the highlight, space toggles the highlighted row and
+// Enter returns the indices of the checked rows in ascending order. Ctrl-C or
+// Esc returns core.ErrCancelled. When stdin is not a terminal the table is
+// printed and comma-separated row numbers or ranges such as "1,3-5" are read
+// instead. A nil theme uses style.DefaultTheme.
+func (t *Table) RunMultiSelect(theme *style.Theme) ([]int, error) {
+	if len(t.rows) == 0 {
+		return nil, errNoRows
+	}
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() {
+		return t.multiSelectByNumber(theme)
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return nil, err
+	}
+	defer guard.Release()
+
+	keys := core.NewKeyReader(core.Stdin())
+	screen := &core.LiveRegion{Writer: out()}
+	checked := make([]bool, len(t.rows))
+	cursor := 0
+	hint := style.ColorOr(theme.Muted, style.Muted).Sprint("↑/↓ move · space toggle · enter confirm · esc cancel")
+
+	for {
+		view := t.checkboxView(checked)
+		view.highlight = cursor
+		screen.Draw(append(strings.Split(view.Render(theme), "\n"), hint))
+
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			return nil, core.ErrAborted
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case key.Type == core.KeyUp || key.Type == core.KeyRune && key.Rune == 'k':
+			cursor = (cursor - 1 + len(t.rows)) % len(t.rows)
+		case key.Type == core.KeyDown || key.Type == core.KeyRune && key.Rune == 'j':
+			cursor = (cursor + 1) % len(t.rows)
+		case key.Type == core.KeyHome:
+			cursor = 0
+		case key.Type == core.KeyEnd:
+			cursor = len(t.rows) - 1
+		case key.Type == core.KeyRune && key.Rune == ' ':
+			checked[cursor] = !checked[cursor]
+		case key.Type == core.KeyEnter:
+			indices := []int{}
+			for i, isChecked := range checked {
+				if isChecked {
+					indices = append(indices, i)
+				}
+			}
+			return indices, nil
+		case key.Type == core.KeyCtrlC || key.Type == core.KeyEscape:
+			return nil, core.ErrCancelled
+		case key.Type == core.KeyCtrlD:
+			return nil, core.ErrAborted
+		}
+	}
+}
+
+// checkboxView returns a copy of the table with a leading "[x]"/"[ ]"
+// column showing which rows are checked. The other columns keep their
+// widths, alignments and formatters.
+func (t *Table) checkboxView(checked []bool) *Table {
+	layout := t.layout()
+
+	view := t.Clone()
+	if len(t.headers) > 0 {
+		view.headers = append([]string{""}, t.headers...)
+	}
+	for r, row := range t.rows {
+		box := "[ ]"
+		if checked[r] {
+			box = "[x]"
+		}
+		view.rows[r] = append([]string{box}, row...)
+	}
+	view.columnWidths = append([]int{3}, t.columnWidths...)
+	view.columnMax = append([]int{0}, t.columnMax...)
+	view.alignment = append([]core.Alignment{core.AlignLeft}, layout.aligns...)
+	view.explicitAlign = len(view.alignment)
+	view.autoAlign = false
+	view.rtlColumns = nil
+	view.formatters = nil
+	for col, format := range t.formatters {
+		if view.formatters == nil {
+			view.formatters = make(map[int]func(string) string)
+		}
+		view.formatters[col+1] = format
+	}
+	if t.frozen > 0 {
+		view.frozen = t.frozen + 1
+	}
+	return view
+}
+
+// multiSelectByNumber prints the table and reads row numbers, asking again
+// until every number is in range. An empty answer selects no rows.
+func (t *Table) multiSelectByNumber(theme *style.Theme) ([]int, error) {
+	fmt.Fprintln(out(), t.Render(theme))
+
+	reader := core.Stdin()
+	for {
+		fmt.Fprint(out(), style.ColorOr(theme.Primary, style.Primary).Sprintf("Select rows [1-%d], e.g. 1,3-4: ", len(t.rows)))
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return nil, core.ErrAborted
+			}
+			return nil, err
+		}
+
+		if indices, parseErr := parseRowNumbers(line, len(t.rows)); parseErr == nil {
+			return indices, nil
+		}
+		fmt.Fprintln(out(), style.ColorOr(theme.Error, style.Error).Sprintf("Please enter row numbers between 1 and %d, e.g. 1,3-4", len(t.rows)))
+		if err == io.EOF {
+			return nil, core.ErrAborted
+		}
+	}
+}
+
+// parseRowNumbers parses comma-separated 1-based row numbers and ranges
+// such as "1,3-5" into sorted 0-based indices without repeats.
+func parseRowNumbers(input string, rows int) ([]int, error) {
+	selected := make([]bool, rows)
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		low, high, ok := core.ParseRange(part)
+		if !ok || low < 1 || high > rows || low > high {
+			return nil, fmt.Errorf("invalid rows %q: numbers must be between 1 and %d", part, rows)
+		}
+		for row := low; row <= high; row++ {
+			selected[row-1] = true
+		}
+	}
+
+	indices := []int{}
+	for i, isSelected := range selected {
+		if isSelected {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
@@ -0,0 +1,89 @@
+// Package ui provides live tables that grow as rows arrive.
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// StreamTheme sets the theme AppendAndRedraw renders with. Nil (the
+// default) uses style.DefaultTheme.
+func (t *Table) StreamTheme(theme *style.Theme) *Table {
+	t.streamTheme = theme
+	return t
+}
+
+// AppendAndRedraw adds a row and updates the table drawn on w, e.g. a
+// terminal, so a long-running command can show results as they arrive:
+//
+//	for result := range results {
+//		table.AppendAndRedraw(os.Stdout, result.Cells())
+//	}
+//
+// The first call draws the whole table. Later calls only print the new row
+// (and move the bottom border below it) as long as the column layout is
+// unchanged. A row that widens a column, or changes a column's automatic
+// alignment, redraws the whole table. That redraw can only erase what is
+// still on screen: once the table is taller than the terminal its old top
+// rows stay behind in the scrollback. Set ColumnWidths up front for columns
+// whose width is known to avoid redraws. Nothing else may be written to w
+// in between.
+func (t *Table) AppendAndRedraw(w io.Writer, row []string) error {
+	t.AddRow(row...)
+
+	theme := t.streamTheme
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	layout := t.layout()
+
+	if t.streamed == 0 || !t.canAppendRow(layout) {
+		output := strings.TrimSuffix(t.Render(theme), "\n")
+		_, err := fmt.Fprint(w, core.ClearLinesSeq(t.streamed)+output+"\n")
+		t.streamed = strings.Count(output, "\n") + 1
+		t.streamLayout = layout
+		return err
+	}
+
+	index := len(t.rows) - 1
+	cells := t.formatRow(t.rows[index])
+	color := t.dataRowColor(theme, index)
+	if !t.border {
+		t.streamed++
+		_, err := fmt.Fprint(w, t.renderRowNoBorder(layout, cells, color)+"\n")
+		return err
+	}
+
+	borderColor := t.borderStyle
+	if borderColor == nil {
+		borderColor = style.ColorOr(theme.Border, style.Border)
+	}
+	t.streamed++
+	_, err := fmt.Fprint(w, core.ClearLinesSeq(1)+
+		t.renderRow(layout, cells, color, borderColor, false)+"\n"+
+		t.renderBottomBorder(layout.widths, borderColor)+"\n")
+	return err
+}
+
+// canAppendRow reports whether the newest row can be printed below the
+// drawn table without redrawing it: the layout is unchanged and no option
+// makes the rows depend on each other.
+func (t *Table) canAppendRow(layout tableLayout) bool {
+	if t.details || t.frozen > 0 || t.scroll > 0 {
+		return false
+	}
+	previous := t.streamLayout
+	if len(layout.widths) != len(previous.widths) {
+		return false
+	}
+	for i := range layout.widths {
+		if layout.widths[i] != previous.widths[i] || layout.aligns[i] != previous.aligns[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,470 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+	"github.com/fatih/color"
+)
+
+func TestTableWidthClamping(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    *Table
+		expected int
+	}{
+		{
+			name:     "Min width widens table",
+			table:    NewTable().Headers("A", "B").AddRow("1", "2").MinWidth(20),
+			expected: 20,
+		},
+		{
+			name:     "Max width narrows table",
+			table:    NewTable().Headers("Name", "Description").AddRow("cmdux", "A terminal UI library").MaxWidth(24),
+			expected: 24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, line := range strings.Split(tt.table.Render(style.DefaultTheme()), "\n") {
+				if width := core.MeasureText(line); width != tt.expected {
+					t.Errorf("Expected width %d, got %d: %q", tt.expected, width, core.StripANSI(line))
+				}
+			}
+		})
+	}
+}
+
+func TestTableWithoutHeader(t *testing.T) {
+	expected := strings.Join([]string{
+		"╭──────┬─────────╮",
+		"│ Name │ cmdux   │",
+		"│ Lang │ Go 1.21 │",
+		"╰──────┴─────────╯",
+	}, "\n")
+
+	headerless := NewTable().
+		AddRow("Name", "cmdux").
+		AddRow("Lang", "Go 1.21")
+	if got := core.StripANSI(headerless.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("Headerless table:\nexpected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	hidden := NewTable().
+		Headers("Key", "Value").
+		AddRow("Name", "cmdux").
+		AddRow("Lang", "Go 1.21").
+		ShowHeader(false)
+	if got := core.StripANSI(hidden.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("Hidden header table:\nexpected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTableHeadersAfterRows(t *testing.T) {
+	expected := strings.Join([]string{
+		"╭────────────┬───────╮",
+		"│ LongHeader │ Other │",
+		"├────────────┼───────┤",
+		"│ a          │ wider │",
+		"╰────────────┴───────╯",
+	}, "\n")
+
+	table := NewTable().Rows([]string{"a", "wider"}).Headers("LongHeader", "Other")
+	if got := core.StripANSI(table.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTableAutoAlign(t *testing.T) {
+	table := NewTable().
+		Headers("Item", "Qty", "Share").
+		AddRow("Apples", "1,200", "12%").
+		AddRow("Kiwis", "7", "").
+		AutoAlign(true)
+
+	lines := strings.Split(core.StripANSI(table.Render(style.DefaultTheme())), "\n")
+	if lines[3] != "│ Apples │ 1,200 │   12% │" || lines[4] != "│ Kiwis  │     7 │       │" {
+		t.Errorf("Numeric columns not right-aligned:\n%s", strings.Join(lines, "\n"))
+	}
+
+	table.Alignment(core.AlignLeft, core.AlignLeft)
+	lines = strings.Split(core.StripANSI(table.Render(style.DefaultTheme())), "\n")
+	if lines[3] != "│ Apples │ 1,200 │   12% │" {
+		t.Errorf("Explicit alignment should override auto-detection: %q", lines[3])
+	}
+	if lines[4] != "│ Kiwis  │ 7     │       │" {
+		t.Errorf("Explicit alignment should override auto-detection: %q", lines[4])
+	}
+}
+
+func TestTableCloneAndConcurrentRender(t *testing.T) {
+	original := NewTable().Headers("Name", "Score").AddRow("Alice", "10")
+	clone := original.Clone().AddRow("Bob", "7")
+
+	if lines := strings.Split(original.Render(style.DefaultTheme()), "\n"); len(lines) != 5 {
+		t.Errorf("Adding a row to the clone changed the original: %d lines", len(lines))
+	}
+
+	themes := []*style.Theme{style.DefaultTheme(), style.DarkTheme(), style.CyberpunkTheme()}
+	expected := core.StripANSI(clone.Render(themes[0]))
+
+	done := make(chan string, len(themes)*10)
+	for i := 0; i < cap(done); i++ {
+		go func(theme *style.Theme) {
+			done <- core.StripANSI(clone.Render(theme))
+		}(themes[i%len(themes)])
+	}
+	for i := 0; i < cap(done); i++ {
+		if got := <-done; got != expected {
+			t.Errorf("Render is not idempotent across themes:\n%s", got)
+		}
+	}
+}
+
+func TestTableTruncateWithDetails(t *testing.T) {
+	table := NewTable().
+		Headers("Name", "Path").
+		AddRow("config", "/etc/cmdux/config.yaml").
+		AddRow("log", "/tmp/x").
+		ColumnMaxWidths(0, 12).
+		TruncateWithDetails(true)
+
+	expected := strings.Join([]string{
+		"╭────────┬──────────────╮",
+		"│ Name   │ Path         │",
+		"├────────┼──────────────┤",
+		"│ config │ /etc/cmd…[1] │",
+		"│ log    │ /tmp/x       │",
+		"╰────────┴──────────────╯",
+		"",
+		"[1] /etc/cmdux/config.yaml",
+	}, "\n")
+	if got := core.StripANSI(table.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTableTruncateWithDetailsNarrow(t *testing.T) {
+	table := NewTable().
+		Headers("Id", "Path").
+		AddRow("1", "/etc/cmdux/config.yaml").
+		ColumnMaxWidths(0, 3).
+		TruncateWithDetails(true)
+
+	expected := strings.Join([]string{
+		"╭────┬─────╮",
+		"│ Id │ Pa… │",
+		"├────┼─────┤",
+		"│ 1  │ /e… │",
+		"╰────┴─────╯",
+	}, "\n")
+	if got := core.StripANSI(table.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected no detail line for a column too narrow for its marker:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTableColumnFormatter(t *testing.T) {
+	table := NewTable().
+		Headers("Item", "Amount", "Date").
+		AddRow("Rent", "1234567.89", "2024-03-05").
+		AddRow("Misc", "-950", "n/a").
+		ColumnFormatter(1, NumberFormatter(",", ".")).
+		ColumnFormatter(2, DateFormatter("2006-01-02", "Jan 2, 2006"))
+
+	expected := strings.Join([]string{
+		"╭──────┬──────────────┬─────────────╮",
+		"│ Item │ Amount       │ Date        │",
+		"├──────┼──────────────┼─────────────┤",
+		"│ Rent │ 1,234,567.89 │ Mar 5, 2024 │",
+		"│ Misc │ -950         │ n/a         │",
+		"╰──────┴──────────────┴─────────────╯",
+	}, "\n")
+	if got := core.StripANSI(table.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestNumberFormatter(t *testing.T) {
+	tests := map[string]string{
+		"1234567.89": "1.234.567,89",
+		"-1000":      "-1.000",
+		"999":        "999",
+		"abc":        "abc",
+		"NaN":        "NaN",
+	}
+	format := NumberFormatter(".", ",")
+	for input, expected := range tests {
+		if got := format(input); got != expected {
+			t.Errorf("format(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestTableCellPadding(t *testing.T) {
+	dense := NewTable().Headers("A", "B").AddRow("1", "2").CellPadding(0)
+	expected := strings.Join([]string{
+		"╭─┬─╮",
+		"│A│B│",
+		"├─┼─┤",
+		"│1│2│",
+		"╰─┴─╯",
+	}, "\n")
+	if got := core.StripANSI(dense.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	roomy := NewTable().Headers("A", "B").AddRow("1", "2").CellPadding(2).MaxWidth(13)
+	for _, line := range strings.Split(roomy.Render(style.DefaultTheme()), "\n") {
+		if width := core.MeasureText(line); width != 13 {
+			t.Errorf("expected width 13, got %d: %q", width, core.StripANSI(line))
+		}
+	}
+}
+
+func TestTableRTLColumn(t *testing.T) {
+	table := NewTable().
+		Headers("English", "עברית").
+		AddRow("Hello", "שלום").
+		AddRow("Peace and welcome", "ברוכים הבאים").
+		RTL(1)
+
+	lines := strings.Split(core.StripANSI(table.Render(style.DefaultTheme())), "\n")
+	if lines[1] != "│ English           │        עברית │" || lines[3] != "│ Hello             │         שלום │" {
+		t.Errorf("RTL column not mirrored:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestTableRenderPlain(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	table := NewTable().Headers("A", "B").AddRow("\x1b[31mred\x1b[0m", "2").Border(false)
+	expected := "A   B\n-----\nred 2"
+	if got := table.RenderPlain(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestTableHighlightRow(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	theme := style.DefaultTheme()
+	theme.Selected = color.New(color.FgMagenta)
+
+	table := NewTable().Headers("Name").AddRow("Alice").AddRow("Bob")
+	table.highlight = 1
+	lines := strings.Split(table.Render(theme), "\n")
+
+	if strings.Contains(lines[3], "\x1b[35m") {
+		t.Errorf("unselected row uses the selected color: %q", lines[3])
+	}
+	if !strings.Contains(lines[4], "\x1b[35m") {
+		t.Errorf("highlighted row missing the selected color: %q", lines[4])
+	}
+}
+
+func TestTableFreezeColumns(t *testing.T) {
+	table := NewTable().
+		Headers("Region", "Jan", "Feb", "Mar", "Apr").
+		AddRow("North", "10", "20", "30", "40").
+		AddRow("South", "15", "25", "35", "45").
+		AutoAlign(true).
+		FreezeColumns(1).
+		MaxWidth(24)
+
+	tests := []struct {
+		scroll int
+		header string
+		row    string
+	}{
+		{0, "│ Region │ Jan │ Feb │", "│ North  │  10 │  20 │"},
+		{2, "│ Region │ Mar │ Apr │", "│ North  │  30 │  40 │"},
+		{9, "│ Region │ Apr │", "│ North  │  40 │"},
+	}
+
+	for _, tt := range tests {
+		lines := strings.Split(table.ScrollColumns(tt.scroll).RenderPlain(), "\n")
+		if lines[1] != tt.header || lines[3] != tt.row {
+			t.Errorf("scroll %d: got\n%s", tt.scroll, strings.Join(lines, "\n"))
+		}
+	}
+}
+
+func TestTableSanitizeCells(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	theme := style.DefaultTheme()
+	theme.Primary = color.New(color.FgBlue)
+
+	hostile := "\x1b[31mred\x1b[0m\x1b[2J\tline\nnext\x07\u009b"
+	table := NewTable().Headers("Log").AddRow(hostile).Border(false).RowStyle(theme.Primary)
+	table.SanitizeCells(true)
+
+	rendered := table.Render(theme)
+	if strings.Contains(rendered, "\x1b[31m") || strings.Contains(rendered, "\x1b[2J") || strings.ContainsAny(rendered, "\t\x07\u009b") {
+		t.Errorf("control sequences leaked into the table: %q", rendered)
+	}
+	if !strings.Contains(rendered, "\x1b[34m") {
+		t.Errorf("expected the table's own row color, got %q", rendered)
+	}
+	if got := core.StripANSI(strings.Split(rendered, "\n")[2]); got != "red line next" {
+		t.Errorf("sanitized row = %q, want %q", got, "red line next")
+	}
+}
+
+func TestTableCheckboxView(t *testing.T) {
+	table := NewTable().Headers("Name", "Size").AutoAlign(true).
+		AddRow("a.txt", "12").
+		AddRow("b.txt", "3")
+
+	view := table.checkboxView([]bool{false, true})
+	expected := strings.Join([]string{
+		"╭─────┬───────┬──────╮",
+		"│     │ Name  │ Size │",
+		"├─────┼───────┼──────┤",
+		"│ [ ] │ a.txt │   12 │",
+		"│ [x] │ b.txt │    3 │",
+		"╰─────┴───────┴──────╯",
+	}, "\n")
+	if got := core.StripANSI(view.Render(style.DefaultTheme())); got != expected {
+		t.Errorf("unexpected checkbox table:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestParseRowNumbers(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{"", "[]", false},
+		{"3, 1", "[0 2]", false},
+		{"2-4,3", "[1 2 3]", false},
+		{"5", "", true},
+		{"x", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRowNumbers(tt.input, 4)
+		if (err != nil) != tt.err || err == nil && fmt.Sprint(got) != tt.want {
+			t.Errorf("parseRowNumbers(%q) = %v, %v; want %s", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestTableAppendAndRedraw(t *testing.T) {
+	table := NewTable().Headers("Host", "Status")
+	var buf bytes.Buffer
+
+	table.AppendAndRedraw(&buf, []string{"web-1", "ok"})
+	if got := core.StripANSI(buf.String()); got != table.RenderPlain()+"\n" {
+		t.Errorf("first row should draw the whole table, got:\n%s", got)
+	}
+
+	// Same layout: only the new row and the bottom border are printed
+	buf.Reset()
+	table.AppendAndRedraw(&buf, []string{"web-2", "ok"})
+	want := core.ClearLinesSeq(1) + "│ web-2 │ ok     │\n╰───────┴────────╯\n"
+	if got := core.StripANSI(buf.String()); got != core.StripANSI(want) || !strings.HasPrefix(buf.String(), core.ClearLinesSeq(1)) {
+		t.Errorf("expected just the new row, got %q", buf.String())
+	}
+
+	// A wider cell redraws the table in place
+	buf.Reset()
+	table.AppendAndRedraw(&buf, []string{"database-1", "ok"})
+	if !strings.HasPrefix(buf.String(), core.ClearLinesSeq(6)) {
+		t.Errorf("expected the 6 drawn lines to be erased, got %q", buf.String())
+	}
+	if got := core.StripANSI(strings.TrimPrefix(buf.String(), core.ClearLinesSeq(6))); got != table.RenderPlain()+"\n" {
+		t.Errorf("expected a full redraw, got:\n%s", got)
+	}
+}
+
+func TestTableRenderContext(t *testing.T) {
+	table := NewTable().Headers("Name", "Description").AddRow("cmdux", "terminal UI toolkit")
+
+	ctx := core.NewRenderContext(style.DefaultTheme())
+	ctx.Width = 24
+	lines := strings.Split(core.StripANSI(table.RenderContext(ctx)), "\n")
+	for _, line := range lines {
+		if width := core.StringWidth(line); width > ctx.Width {
+			t.Errorf("line %q is %d cells wide, want at most %d", line, width, ctx.Width)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "╭") {
+		t.Errorf("default symbols should keep rounded corners, got %q", lines[0])
+	}
+
+	ctx.Symbols = style.ASCIISymbols()
+	if output := core.StripANSI(table.MaxWidth(40).RenderContext(ctx)); strings.ContainsAny(output, "╭┬─│┼╯") || !strings.Contains(output, "terminal UI toolkit") {
+		t.Errorf("expected ASCII borders and an explicit MaxWidth to win, got\n%s", output)
+	}
+}
+
+func TestTableRunSelectOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.WriteString("5\n2\n")
+	w.Close()
+
+	table := NewTable().Headers("Name").AddRow("alpha").AddRow("beta")
+	if index, err := table.RunSelect(style.DefaultTheme()); index != 1 || err != nil {
+		t.Fatalf("RunSelect = %d, %v; want 1", index, err)
+	}
+	got := core.StripANSI(buf.String())
+	for _, want := range []string{"beta", "Select a row [1-2]: ", "Please enter a number between 1 and 2"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q does not contain %q", got, want)
+		}
+	}
+}
+
+func TestTableRunSelectSharesStdin(t *testing.T) {
+	SetOutput(io.Discard)
+	defer SetOutput(nil)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.WriteString("2\n1-2\n1-2\nnext answer\n")
+	w.Close()
+
+	table := NewTable().AddRow("alpha").AddRow("beta")
+	if index, err := table.RunSelect(nil); index != 1 || err != nil {
+		t.Fatalf("RunSelect(nil) = %d, %v; want 1", index, err)
+	}
+	if rest, _ := core.Stdin().ReadString('\n'); rest != "1-2\n" {
+		t.Errorf("input after the selection = %q, want it left for the next reader", rest)
+	}
+	if indices, err := table.RunMultiSelect(nil); fmt.Sprint(indices) != "[0 1]" || err != nil {
+		t.Errorf("RunMultiSelect(nil) = %v, %v; want [0 1]", indices, err)
+	}
+	if rest, _ := core.Stdin().ReadString('\n'); rest != "next answer\n" {
+		t.Errorf("input after the multi-selection = %q, want it left for the next reader", rest)
+	}
+}
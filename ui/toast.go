@@ -0,0 +1,107 @@
+// Package ui provides notification components.
+package ui
+
+import (
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// ToastLevel is the severity of a toast notification.
+type ToastLevel string
+
+const (
+	// ToastInfo is a neutral notice, drawn with an info icon in the primary color.
+	ToastInfo ToastLevel = "info"
+	// ToastSuccess reports a completed operation, drawn with a check mark.
+	ToastSuccess ToastLevel = "success"
+	// ToastWarning reports a problem that did not stop the operation.
+	ToastWarning ToastLevel = "warning"
+	// ToastError reports a failed operation, drawn with a cross mark.
+	ToastError ToastLevel = "error"
+)
+
+// Toast is a notification block: an icon and title over a message, framed in
+// a box colored by severity.
+type Toast struct {
+	*core.Component
+	level   ToastLevel
+	title   string
+	message string
+}
+
+// NewToast creates a toast with the given severity, title and message.
+func NewToast(level ToastLevel, title, message string) *Toast {
+	return &Toast{
+		Component: core.NewComponent(),
+		level:     level,
+		title:     title,
+		message:   message,
+	}
+}
+
+// Clone returns an independent copy of the toast.
+func (t *Toast) Clone() *Toast {
+	clone := *t
+	clone.Component = t.Component.Clone()
+	return &clone
+}
+
+// Width sets the toast width. By default it fits its content.
+func (t *Toast) Width(w int) *Toast {
+	t.Component.Width(w)
+	return t
+}
+
+// Render renders the toast using the given theme.
+func (t *Toast) Render(theme *style.Theme) string {
+	return t.RenderContext(core.NewRenderContext(theme))
+}
+
+// RenderContext renders the toast under ctx, taking its icon and border
+// glyphs from ctx.Symbols.
+func (t *Toast) RenderContext(ctx core.RenderContext) string {
+	if t.IsHidden() {
+		return ""
+	}
+	theme := ctx.Theme
+
+	symbol, color := t.appearance(theme, ctx.Symbols)
+
+	title := symbol
+	if t.title != "" {
+		title += " " + t.title
+	}
+
+	return NewBox().
+		Title(title).
+		Content(t.message).
+		Width(t.GetWidth()).
+		BorderStyle(color).
+		TitleStyle(color).
+		ContentStyle(style.ColorOr(theme.Primary, style.Primary)).
+		RenderContext(ctx)
+}
+
+// appearance returns the symbol and color for the toast level. Glyphs
+// missing from symbols fall back to the default ones.
+func (t *Toast) appearance(theme *style.Theme, symbols style.SymbolSet) (string, *style.Color) {
+	defaults := style.DefaultSymbols()
+	switch t.level {
+	case ToastSuccess:
+		return glyphOr(symbols.CheckMark, defaults.CheckMark), style.ColorOr(theme.Success, style.Success)
+	case ToastWarning:
+		return glyphOr(symbols.Warning, defaults.Warning), style.ColorOr(theme.Warning, style.Warning)
+	case ToastError:
+		return glyphOr(symbols.CrossMark, defaults.CrossMark), style.ColorOr(theme.Error, style.Error)
+	default:
+		return glyphOr(symbols.Info, defaults.Info), style.ColorOr(theme.Primary, style.Primary)
+	}
+}
+
+// glyphOr returns glyph, or fallback if it is empty.
+func glyphOr(glyph, fallback string) string {
+	if glyph == "" {
+		return fallback
+	}
+	return glyph
+}
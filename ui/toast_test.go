@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+func TestToastRender(t *testing.T) {
+	tests := []struct {
+		level ToastLevel
+		icon  string
+	}{
+		{ToastInfo, "ℹ"},
+		{ToastSuccess, "✓"},
+		{ToastWarning, "⚠"},
+		{ToastError, "✗"},
+	}
+
+	for _, tt := range tests {
+		output := core.StripANSI(NewToast(tt.level, "Deploy", "All services updated").Render(style.DefaultTheme()))
+		lines := strings.Split(output, "\n")
+		if !strings.Contains(lines[0], tt.icon+" Deploy") {
+			t.Errorf("%s: expected %q in the title, got %q", tt.level, tt.icon+" Deploy", lines[0])
+		}
+		if !strings.Contains(output, "All services updated") {
+			t.Errorf("%s: message missing from\n%s", tt.level, output)
+		}
+		for _, line := range lines[1:] {
+			if core.StringWidth(line) != core.StringWidth(lines[0]) {
+				t.Errorf("%s: ragged box\n%s", tt.level, output)
+				break
+			}
+		}
+	}
+
+	toast := NewToast(ToastInfo, "", "hidden")
+	toast.Hide()
+	if output := toast.Render(style.DefaultTheme()); output != "" {
+		t.Errorf("a hidden toast should render nothing, got %q", output)
+	}
+}
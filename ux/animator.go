@@ -0,0 +1,53 @@
+// Package ux provides frame timing for animations.
+package ux
+
+import "time"
+
+// defaultFPS is the frame rate used when an Animator is given a non-positive fps.
+const defaultFPS = 20
+
+// Animator calls a draw function at a steady frame rate. It measures how long
+// each frame took to draw and sleeps only for the rest of the frame interval;
+// when drawing falls behind, the late frames are skipped so the animation
+// keeps its pace on slow terminals.
+type Animator struct {
+	fps      int
+	interval time.Duration
+}
+
+// NewAnimator creates an animator targeting fps frames per second.
+func NewAnimator(fps int) *Animator {
+	if fps <= 0 {
+		fps = defaultFPS
+	}
+	return &Animator{
+		fps:      fps,
+		interval: time.Second / time.Duration(fps),
+	}
+}
+
+// FPS returns the target frame rate.
+func (a *Animator) FPS() int {
+	return a.fps
+}
+
+// Run draws frames until duration has elapsed. draw receives the index of the
+// frame due at the current time, which advances by more than one after
+// skipped frames.
+func (a *Animator) Run(duration time.Duration, draw func(frame int)) {
+	start := time.Now()
+	for {
+		elapsed := time.Since(start)
+		if elapsed >= duration {
+			return
+		}
+
+		frame := int(elapsed / a.interval)
+		draw(frame)
+
+		next := start.Add(time.Duration(frame+1) * a.interval)
+		if wait := time.Until(next); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+}
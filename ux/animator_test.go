@@ -0,0 +1,23 @@
+package ux
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnimatorSkipsLateFrames(t *testing.T) {
+	var frames []int
+	NewAnimator(100).Run(100*time.Millisecond, func(frame int) {
+		frames = append(frames, frame)
+		time.Sleep(25 * time.Millisecond) // Slower than the 10ms frame interval
+	})
+
+	if len(frames) == 0 || len(frames) > 5 {
+		t.Fatalf("expected 1-5 drawn frames, got %v", frames)
+	}
+	for i := 1; i < len(frames); i++ {
+		if frames[i] <= frames[i-1]+1 {
+			t.Errorf("expected late frames to be skipped, got %v", frames)
+		}
+	}
+}
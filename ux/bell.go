@@ -0,0 +1,28 @@
+// Package ux provides terminal bell notifications.
+package ux
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// quiet silences the terminal bell; see SetQuiet. It is atomic since
+// spinners and progress bars ring the bell from their own goroutines.
+var quiet atomic.Bool
+
+// SetQuiet silences Bell and with it the BellOnComplete notifications of
+// spinners and progress bars, e.g. for a --quiet flag.
+func SetQuiet(enabled bool) {
+	quiet.Store(enabled)
+}
+
+// Bell rings the terminal bell, so a user who switched away from a long task
+// notices it finished. It does nothing when quiet or when output (see
+// SetOutput) is not a terminal, keeping redirected output free of control
+// characters.
+func Bell() {
+	if quiet.Load() || !outIsTerminal() {
+		return
+	}
+	fmt.Fprint(out(), "\a")
+}
@@ -3,62 +3,222 @@ package ux
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
 )
 
-// TypewriterEffect displays text character by character with a typewriter effect.
-func TypewriterEffect(text string, delay time.Duration, color ...*style.Color) {
-	textColor := style.Primary
-	if len(color) > 0 {
-		textColor = color[0]
+// Effects take their colors as a trailing variadic parameter. The first color
+// is the main text color; effects that use several colors take them in order,
+// e.g. the cycle of PulseEffect or the stripes of RainbowEffect. Colors that
+// are not passed default to the palette of the theme set with SetEffectTheme.
+
+// effectTheme supplies the default effect colors. It is guarded by
+// effectThemeMu, since effects may run while the theme is switched.
+var (
+	effectThemeMu sync.RWMutex
+	effectTheme   = style.DefaultTheme()
+)
+
+// SetEffectTheme sets the theme effects derive their default colors from, so
+// e.g. a cyberpunk-themed app gets a matching rainbow. A nil theme restores
+// the default one.
+func SetEffectTheme(theme *style.Theme) {
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	effectThemeMu.Lock()
+	effectTheme = theme
+	effectThemeMu.Unlock()
+}
+
+// currentEffectTheme returns the theme set with SetEffectTheme.
+func currentEffectTheme() *style.Theme {
+	effectThemeMu.RLock()
+	defer effectThemeMu.RUnlock()
+	return effectTheme
+}
+
+// effectOut receives the output of all effects.
+var effectOut io.Writer = os.Stdout
+
+// effectRand drives the randomness of MatrixEffect and GlitchEffect when
+// set with SetEffectRand; by default they use the package-level math/rand
+// functions. A *rand.Rand is not safe for concurrent use, so every use holds
+// effectRandMu.
+var (
+	effectRandMu sync.Mutex
+	effectRand   *rand.Rand
+)
+
+// SetEffectOutput sets where effects draw, os.Stdout by default. With a
+// buffer, a test can capture the frames of an effect; pass a short duration
+// to render just the first frame.
+func SetEffectOutput(w io.Writer) {
+	effectOut = w
+}
+
+// SetEffectRand sets the source of randomness for MatrixEffect and
+// GlitchEffect, so their output can be reproduced:
+//
+//	ux.SetEffectRand(rand.New(rand.NewSource(42)))
+//
+// Effects running in parallel share the source, so their output is only
+// reproducible when they run one at a time. Nil restores the default
+// source.
+func SetEffectRand(r *rand.Rand) {
+	effectRandMu.Lock()
+	effectRand = r
+	effectRandMu.Unlock()
+}
+
+// effectIntn returns a random number in [0, n) from the effect source.
+func effectIntn(n int) int {
+	effectRandMu.Lock()
+	defer effectRandMu.Unlock()
+	if effectRand == nil {
+		return rand.Intn(n)
+	}
+	return effectRand.Intn(n)
+}
+
+// effectFloat32 returns a random number in [0, 1) from the effect source.
+func effectFloat32() float32 {
+	effectRandMu.Lock()
+	defer effectRandMu.Unlock()
+	if effectRand == nil {
+		return rand.Float32()
 	}
+	return effectRand.Float32()
+}
+
+// preserveScreen makes full-screen effects draw in place instead of clearing
+// the screen. It is atomic since effects may run while it is switched.
+var preserveScreen atomic.Bool
+
+// SetPreserveScreen makes full-screen effects such as MatrixEffect and
+// WaveEffect draw below the existing output and erase only their own lines
+// when done, instead of clearing the whole screen, which on many terminals
+// also wipes the scrollback.
+func SetPreserveScreen(enabled bool) {
+	preserveScreen.Store(enabled)
+}
+
+// effectCanvas positions the frames of a multi-line effect: over a cleared
+// screen, or in place when the screen is preserved.
+type effectCanvas struct {
+	height int
+	drawn  bool
+}
+
+// frame prepares the terminal for drawing the next frame.
+func (c *effectCanvas) frame() {
+	switch {
+	case !preserveScreen.Load():
+		fmt.Fprint(effectOut, "\033[2J\033[H") // Clear screen
+	case c.drawn:
+		fmt.Fprintf(effectOut, "\033[%dA\r", c.height) // Back to the first line of the frame
+	}
+	c.drawn = true
+}
+
+// finish removes the effect, or in full-screen mode runs clear to leave the
+// screen as the effect expects.
+func (c *effectCanvas) finish(clear string) {
+	if !preserveScreen.Load() {
+		fmt.Fprint(effectOut, clear)
+		return
+	}
+	if c.drawn {
+		fmt.Fprint(effectOut, core.ClearLinesSeq(c.height))
+	}
+}
+
+// effectColors returns the given colors, filling positions that were not
+// passed from defaults.
+func effectColors(colors []*style.Color, defaults ...*style.Color) []*style.Color {
+	if len(colors) >= len(defaults) {
+		return colors
+	}
+	return append(append([]*style.Color(nil), colors...), defaults[len(colors):]...)
+}
+
+// hideCursor hides the cursor for the duration of an effect and returns the
+// function that shows it again.
+func hideCursor() func() {
+	cursor := core.NewCursorGuard(effectOut)
+	cursor.Hide()
+	return cursor.Show
+}
+
+// TypewriterEffect displays text character by character with a typewriter effect.
+func TypewriterEffect(text string, delay time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	textColor := effectColors(colors, style.ColorOr(theme.Primary, style.Primary))[0]
 	
 	for _, char := range text {
-		fmt.Print(textColor.Sprint(string(char)))
+		fmt.Fprint(effectOut, textColor.Sprint(string(char)))
 		time.Sleep(delay)
 	}
-	fmt.Println()
+	fmt.Fprintln(effectOut)
 }
 
-// MatrixEffect creates a matrix-style rain effect.
-func MatrixEffect(duration time.Duration) {
+// MatrixEffect creates a matrix-style rain effect. The colors are the drop
+// head and trail colors.
+func MatrixEffect(duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	colors = effectColors(colors,
+		style.ColorOr(theme.Success, style.Success),
+		style.ColorOr(theme.Muted, style.Muted))
+	headColor, trailColor := colors[0], colors[1]
+
 	width, height := 80, 15
-	chars := "アイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワヲン0123456789"
+	chars := []rune("アイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワヲン0123456789")
 
 	drops := make([]struct{ x, y, speed int }, width)
 	for i := range drops {
 		drops[i] = struct{ x, y, speed int }{
 			x:     i,
-			y:     rand.Intn(height),
-			speed: 1 + rand.Intn(3),
+			y:     effectIntn(height),
+			speed: 1 + effectIntn(3),
 		}
 	}
 
-	startTime := time.Now()
-	for time.Since(startTime) < duration {
+	canvas := &effectCanvas{height: height}
+	last := 0
+	NewAnimator(20).Run(duration, func(n int) {
 		frame := make([][]rune, height)
 		for i := range frame {
 			frame[i] = []rune(strings.Repeat(" ", width))
 		}
 
+		// Advance drops by the frames elapsed, including skipped ones
+		steps := n - last
+		last = n
+
 		// Update and draw drops
 		for i, drop := range drops {
-			drop.y += drop.speed
+			drop.y += drop.speed * steps
 			if drop.y >= height {
 				drop.y = 0
-				drop.x = rand.Intn(width)
+				drop.x = effectIntn(width)
 			}
 			drops[i] = drop
 
 			for y := 0; y < height; y++ {
 				if y >= drop.y-5 && y <= drop.y {
-					charIndex := rand.Intn(len(chars))
-					char := rune(chars[charIndex])
+					charIndex := effectIntn(len(chars))
+					char := chars[charIndex]
 					if drop.x < width && y >= 0 {
 						frame[y][drop.x] = char
 					}
@@ -66,39 +226,39 @@ func MatrixEffect(duration time.Duration) {
 			}
 		}
 
-		fmt.Print("\033[2J\033[H") // Clear screen
+		canvas.frame()
 		for y, line := range frame {
 			for x, char := range line {
 				if char != ' ' {
 					// Color based on position for trail effect
 					if y > drops[x%len(drops)].y-2 {
-						style.Success.Print(string(char))
+						fmt.Fprint(effectOut, headColor.Sprint(string(char)))
 					} else {
-						style.Muted.Print(string(char))
+						fmt.Fprint(effectOut, trailColor.Sprint(string(char)))
 					}
 				} else {
-					fmt.Print(" ")
+					fmt.Fprint(effectOut, " ")
 				}
 			}
-			fmt.Println()
+			fmt.Fprintln(effectOut)
 		}
-		time.Sleep(50 * time.Millisecond)
-	}
-	fmt.Print("\033[2J\033[H") // Clear screen
+	})
+	canvas.finish("\033[2J\033[H") // Clear screen
 }
 
 // WaveEffect creates a wave animation with text.
-func WaveEffect(text string, duration time.Duration, color ...*style.Color) {
-	textColor := style.Primary
-	if len(color) > 0 {
-		textColor = color[0]
-	}
+func WaveEffect(text string, duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	textColor := effectColors(colors, style.ColorOr(theme.Primary, style.Primary))[0]
 	
 	width := 80
 	height := 5
 	startTime := time.Now()
+	canvas := &effectCanvas{height: height}
 
-	for time.Since(startTime) < duration {
+	NewAnimator(20).Run(duration, func(int) {
 		frame := make([]string, height)
 		for i := range frame {
 			frame[i] = strings.Repeat(" ", width)
@@ -116,154 +276,161 @@ func WaveEffect(text string, duration time.Duration, color ...*style.Color) {
 			}
 		}
 
-		// Clear screen and print frame
-		fmt.Print("\033[2J\033[H")
+		canvas.frame()
 		for _, line := range frame {
-			textColor.Println(line)
+			fmt.Fprintln(effectOut, textColor.Sprint(line))
 		}
-		time.Sleep(50 * time.Millisecond)
-	}
+	})
 
 	// Reset cursor position
-	fmt.Print("\033[H")
+	canvas.finish("\033[H")
 }
 
-// GlitchEffect creates a glitch-style text effect.
-func GlitchEffect(text string, duration time.Duration, color ...*style.Color) {
-	glitchColor := style.Error
-	normalColor := style.Primary
-	if len(color) > 0 {
-		normalColor = color[0]
-	}
+// GlitchEffect creates a glitch-style text effect. The colors are the text
+// color and the color of glitched frames.
+func GlitchEffect(text string, duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	colors = effectColors(colors,
+		style.ColorOr(theme.Primary, style.Primary),
+		style.ColorOr(theme.Error, style.Error))
+	normalColor, glitchColor := colors[0], colors[1]
 	
 	glitchChars := "$#@!%^*&*()_+-=[]{}|;:,.<>?"
-	startTime := time.Now()
 
-	for time.Since(startTime) < duration {
-		fmt.Print("\033[2K\r") // Clear line
+	NewAnimator(10).Run(duration, func(int) {
+		fmt.Fprint(effectOut, "\033[2K\r") // Clear line
 
 		glitched := ""
 		for _, char := range text {
-			if rand.Float32() < 0.1 {
-				glitched += string(glitchChars[rand.Intn(len(glitchChars))])
+			if effectFloat32() < 0.1 {
+				glitched += string(glitchChars[effectIntn(len(glitchChars))])
 			} else {
 				glitched += string(char)
 			}
 		}
 
-		if rand.Float32() < 0.3 {
-			glitchColor.Printf("%s", glitched)
+		if effectFloat32() < 0.3 {
+			fmt.Fprint(effectOut, glitchColor.Sprintf("%s", glitched))
 		} else {
-			normalColor.Printf("%s", glitched)
+			fmt.Fprint(effectOut, normalColor.Sprintf("%s", glitched))
 		}
-
-		time.Sleep(100 * time.Millisecond)
-	}
+	})
 	
 	// Show final clean text
-	fmt.Print("\033[2K\r")
-	normalColor.Println(text)
+	fmt.Fprint(effectOut, "\033[2K\r")
+	fmt.Fprintln(effectOut, normalColor.Sprint(text))
 }
 
-// PulseEffect creates a pulsing color effect.
+// PulseEffect creates a pulsing color effect, cycling through the colors and
+// ending on the first.
 func PulseEffect(text string, duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
 	if len(colors) == 0 {
-		colors = []*style.Color{style.Primary, style.Secondary, style.Accent1}
+		colors = []*style.Color{
+			style.ColorOr(theme.Primary, style.Primary),
+			style.ColorOr(theme.Secondary, style.Secondary),
+			style.ColorOr(theme.Accent1, style.Accent1),
+		}
 	}
 	
-	startTime := time.Now()
-	i := 0
+	NewAnimator(5).Run(duration, func(frame int) {
+		fmt.Fprint(effectOut, "\033[2K\r") // Clear line
+		fmt.Fprint(effectOut, colors[frame%len(colors)].Sprint(text))
+	})
 	
-	for time.Since(startTime) < duration {
-		fmt.Print("\033[2K\r") // Clear line
-		color := colors[i%len(colors)]
-		color.Print(text)
-		time.Sleep(200 * time.Millisecond)
-		i++
-	}
-	
-	fmt.Print("\033[2K\r")
-	style.Primary.Println(text)
+	fmt.Fprint(effectOut, "\033[2K\r")
+	fmt.Fprintln(effectOut, colors[0].Sprint(text))
 }
 
 // FadeInEffect creates a fade-in effect by gradually increasing brightness.
-func FadeInEffect(text string, steps int, stepDelay time.Duration) {
-	colors := []*style.Color{
-		style.Faint,
-		style.Muted,
-		style.Secondary,
-		style.Primary,
+// The colors run from dimmest to brightest; at most steps of them are shown.
+func FadeInEffect(text string, steps int, stepDelay time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	if len(colors) == 0 {
+		colors = []*style.Color{
+			style.ColorOr(theme.Faint, style.Faint),
+			style.ColorOr(theme.Muted, style.Muted),
+			style.ColorOr(theme.Secondary, style.Secondary),
+			style.ColorOr(theme.Primary, style.Primary),
+		}
 	}
 	
 	for i := 0; i < steps && i < len(colors); i++ {
-		fmt.Print("\033[2K\r") // Clear line
-		colors[i].Print(text)
+		fmt.Fprint(effectOut, "\033[2K\r") // Clear line
+		fmt.Fprint(effectOut, colors[i].Sprint(text))
 		time.Sleep(stepDelay)
 	}
-	fmt.Println()
+	fmt.Fprintln(effectOut)
 }
 
-// RainbowEffect displays text with rainbow colors.
-func RainbowEffect(text string) {
-	colors := []*style.Color{
-		style.Error,    // Red
-		style.Warning,  // Yellow
-		style.Success,  // Green
-		style.Primary,  // Cyan
-		style.Secondary, // Blue
-		style.Accent1,  // Magenta
+// RainbowEffect displays text with rainbow colors, one color per character.
+func RainbowEffect(text string, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	if len(colors) == 0 {
+		colors = []*style.Color{
+			style.ColorOr(theme.Error, style.Error),         // Red
+			style.ColorOr(theme.Warning, style.Warning),     // Yellow
+			style.ColorOr(theme.Success, style.Success),     // Green
+			style.ColorOr(theme.Primary, style.Primary),     // Cyan
+			style.ColorOr(theme.Secondary, style.Secondary), // Blue
+			style.ColorOr(theme.Accent1, style.Accent1),     // Magenta
+		}
 	}
 
 	for i, char := range text {
 		if char != ' ' {
-			colors[i%len(colors)].Print(string(char))
+			fmt.Fprint(effectOut, colors[i%len(colors)].Sprint(string(char)))
 		} else {
-			fmt.Print(" ")
+			fmt.Fprint(effectOut, " ")
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(effectOut)
 }
 
-// BreathingEffect creates a breathing pulse effect.
-func BreathingEffect(text string, duration time.Duration, color ...*style.Color) {
-	textColor := style.Success
-	if len(color) > 0 {
-		textColor = color[0]
-	}
+// BreathingEffect creates a breathing pulse effect. The colors are the text
+// color and the dimmed color it fades to.
+func BreathingEffect(text string, duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	colors = effectColors(colors,
+		style.ColorOr(theme.Success, style.Success),
+		style.ColorOr(theme.Muted, style.Muted))
+	textColor, dimColor := colors[0], colors[1]
 	
-	startTime := time.Now()
-	for time.Since(startTime) < duration {
-		// Create breathing effect
-		fmt.Print("\033[2K\r")
-		textColor.Printf("%s", text)
-		time.Sleep(500 * time.Millisecond)
-		
-		fmt.Print("\033[2K\r")
-		style.Muted.Printf("%s", text)
-		time.Sleep(500 * time.Millisecond)
-	}
-	fmt.Print("\033[2K\r")
-	textColor.Println(text)
+	// Alternate between the text color and muted every half second
+	NewAnimator(2).Run(duration, func(frame int) {
+		fmt.Fprint(effectOut, "\033[2K\r")
+		if frame%2 == 0 {
+			fmt.Fprint(effectOut, textColor.Sprintf("%s", text))
+		} else {
+			fmt.Fprint(effectOut, dimColor.Sprintf("%s", text))
+		}
+	})
+	fmt.Fprint(effectOut, "\033[2K\r")
+	fmt.Fprintln(effectOut, textColor.Sprint(text))
 }
 
 // LoadingDots creates animated loading dots.
-func LoadingDots(text string, duration time.Duration, color ...*style.Color) {
-	textColor := style.Primary
-	if len(color) > 0 {
-		textColor = color[0]
-	}
+func LoadingDots(text string, duration time.Duration, colors ...*style.Color) {
+	theme := currentEffectTheme()
+	defer hideCursor()()
+
+	textColor := effectColors(colors, style.ColorOr(theme.Primary, style.Primary))[0]
 	
 	dots := []string{"", ".", "..", "..."}
-	startTime := time.Now()
-	i := 0
-	
-	for time.Since(startTime) < duration {
-		fmt.Print("\033[2K\r") // Clear line
-		textColor.Printf("%s%s", text, dots[i%len(dots)])
-		time.Sleep(300 * time.Millisecond)
-		i++
-	}
+
+	NewAnimator(3).Run(duration, func(frame int) {
+		fmt.Fprint(effectOut, "\033[2K\r") // Clear line
+		fmt.Fprint(effectOut, textColor.Sprintf("%s%s", text, dots[frame%len(dots)]))
+	})
 	
-	fmt.Print("\033[2K\r")
-	textColor.Println(text)
+	fmt.Fprint(effectOut, "\033[2K\r")
+	fmt.Fprintln(effectOut, textColor.Sprint(text))
 }
\ No newline at end of file
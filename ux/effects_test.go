@@ -0,0 +1,135 @@
+package ux
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bagaking/cmdux/style"
+	"github.com/fatih/color"
+)
+
+// renderEffect captures the output of an effect run with a fixed seed.
+func renderEffect(seed int64, effect func()) string {
+	var buf bytes.Buffer
+	SetEffectOutput(&buf)
+	SetEffectRand(rand.New(rand.NewSource(seed)))
+	defer func() {
+		SetEffectOutput(os.Stdout)
+		SetEffectRand(nil)
+	}()
+
+	effect()
+	return buf.String()
+}
+
+func TestEffectsAreReproducible(t *testing.T) {
+	effects := map[string]func(){
+		// Durations shorter than one frame interval render a single frame
+		"matrix": func() { MatrixEffect(10 * time.Millisecond) },
+		"glitch": func() { GlitchEffect("signal lost in the noise", 10*time.Millisecond) },
+	}
+
+	for name, effect := range effects {
+		first, second := renderEffect(7, effect), renderEffect(7, effect)
+		if first == "" || first != second {
+			t.Errorf("%s: expected identical output for the same seed", name)
+		}
+	}
+}
+
+func TestMatrixEffectFrame(t *testing.T) {
+	SetPreserveScreen(true)
+	defer SetPreserveScreen(false)
+
+	output := renderEffect(1, func() { MatrixEffect(10 * time.Millisecond) })
+	lines := strings.Split(output, "\n")
+	if len(lines) != 16 { // 15 frame lines, then the sequence erasing them
+		t.Fatalf("expected a 15-line frame, got %d lines", len(lines)-1)
+	}
+	if !strings.HasPrefix(lines[15], "\033[1A\033[2K") {
+		t.Errorf("expected the frame to be erased in place, got %q", lines[15])
+	}
+}
+
+func TestSetEffectTheme(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+	defer SetEffectTheme(nil)
+
+	theme := style.DefaultTheme()
+	theme.Primary = color.New(color.FgRed)
+	SetEffectTheme(theme)
+	if output := renderEffect(1, func() { RainbowEffect("x") }); output == "" {
+		t.Fatal("expected output")
+	}
+	if output := renderEffect(1, func() { TypewriterEffect("x", 0) }); !strings.Contains(output, "\033[31m") {
+		t.Errorf("expected the theme's primary color, got %q", output)
+	}
+
+	SetEffectTheme(nil)
+	want := style.DefaultTheme().Primary.Sprint("x")
+	if output := renderEffect(1, func() { TypewriterEffect("x", 0) }); output != want+"\n" {
+		t.Errorf("nil should restore the default theme, got %q, want %q", output, want+"\n")
+	}
+	SetOutput(io.Discard)
+	defer SetOutput(nil)
+	if err := WithRetry(1, func() error { return nil }, RetryOptions{}); err != nil {
+		t.Errorf("WithRetry = %v", err)
+	}
+
+	// Switching the theme while effects run must not race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetEffectTheme(theme)
+			SetEffectTheme(nil)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		currentEffectTheme()
+	}
+	<-done
+}
+
+func TestEffectRandConcurrent(t *testing.T) {
+	SetEffectOutput(io.Discard)
+	defer func() {
+		SetEffectOutput(os.Stdout)
+		SetEffectRand(nil)
+	}()
+
+	for _, source := range []*rand.Rand{rand.New(rand.NewSource(1)), nil} {
+		SetEffectRand(source)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				GlitchEffect("signal", 10*time.Millisecond)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestSetPreserveScreen(t *testing.T) {
+	output := renderEffect(1, func() { MatrixEffect(10 * time.Millisecond) })
+	if !strings.Contains(output, "\033[2J\033[H") {
+		t.Errorf("expected the screen to be cleared by default, got %q", output)
+	}
+
+	SetPreserveScreen(true)
+	defer SetPreserveScreen(false)
+	output = renderEffect(1, func() { MatrixEffect(10 * time.Millisecond) })
+	if strings.Contains(output, "\033[2J") {
+		t.Error("a preserved screen must not be cleared")
+	}
+}
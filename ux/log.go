@@ -0,0 +1,168 @@
+// Package ux provides log-style output with level tags.
+package ux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/bagaking/cmdux/style"
+)
+
+// LogLevel is the severity of a log line.
+type LogLevel int
+
+const (
+	// LevelDebug is for details only useful when diagnosing a problem.
+	LevelDebug LogLevel = iota
+	// LevelInfo is for routine progress, such as a finished step.
+	LevelInfo
+	// LevelWarn is for problems the command recovered from.
+	LevelWarn
+	// LevelError is for failures the user has to act on.
+	LevelError
+)
+
+// String returns the level's tag text, e.g. "WARN".
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL%d", int(l))
+	}
+}
+
+// logTagWidth fits the longest tag, "[DEBUG]" or "[ERROR]", so messages line up.
+const logTagWidth = 7
+
+// Logger writes log lines prefixed with a colored, fixed-width level tag:
+//
+//	[INFO]  Fetched 12 releases
+//	[WARN]  Release v1.2 has no changelog
+//	[ERROR] Upload failed: connection reset
+type Logger struct {
+	writer io.Writer
+	theme  *style.Theme
+}
+
+// NewLogger creates a logger writing to w with tag colors from theme. A nil
+// theme uses the default colors.
+func NewLogger(w io.Writer, theme *style.Theme) *Logger {
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	return &Logger{writer: w, theme: theme}
+}
+
+// Log writes one line at level. Multi-line messages are indented to the
+// message column. The tag of a level other than the four above, such as
+// "[LEVEL5]", may be wider than the others and push its message right.
+func (l *Logger) Log(level LogLevel, msg string) {
+	tag := "[" + level.String() + "]"
+	column := max(logTagWidth, len(tag)) + 1
+	padding := strings.Repeat(" ", column-len(tag))
+	msg = strings.ReplaceAll(strings.TrimRight(msg, "\n"), "\n", "\n"+strings.Repeat(" ", column))
+	fmt.Fprintln(l.writer, l.levelColor(level).Sprint(tag)+padding+msg)
+}
+
+// levelColor returns the theme color of a level's tag.
+func (l *Logger) levelColor(level LogLevel) *style.Color {
+	switch level {
+	case LevelDebug:
+		return style.ColorOr(l.theme.Muted, style.Muted)
+	case LevelWarn:
+		return style.ColorOr(l.theme.Warning, style.Warning)
+	case LevelError:
+		return style.ColorOr(l.theme.Error, style.Error)
+	default:
+		return style.ColorOr(l.theme.Primary, style.Primary)
+	}
+}
+
+// Debug writes a debug line.
+func (l *Logger) Debug(msg string) { l.Log(LevelDebug, msg) }
+
+// Info writes an info line.
+func (l *Logger) Info(msg string) { l.Log(LevelInfo, msg) }
+
+// Warn writes a warning line.
+func (l *Logger) Warn(msg string) { l.Log(LevelWarn, msg) }
+
+// Error writes an error line.
+func (l *Logger) Error(msg string) { l.Log(LevelError, msg) }
+
+// Debugf writes a formatted debug line.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof writes a formatted info line.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf writes a formatted warning line.
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf writes a formatted error line.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.Log(LevelError, fmt.Sprintf(format, args...))
+}
+
+// defaultLogger replaces stdoutLogger behind the package-level log
+// functions; see SetLogger. It is atomic so the logger can be replaced while
+// other goroutines log.
+var (
+	defaultLogger atomic.Pointer[Logger]
+	stdoutLogger  = NewLogger(os.Stdout, nil)
+)
+
+// SetLogger replaces the logger used by the package-level log functions, e.g.
+// with one writing to os.Stderr in the app's theme. A nil logger restores the
+// default one writing to os.Stdout.
+func SetLogger(l *Logger) {
+	defaultLogger.Store(l)
+}
+
+// logger returns the logger the package-level log functions write with.
+func logger() *Logger {
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
+	return stdoutLogger
+}
+
+// Debug writes a debug line with the default logger.
+func Debug(msg string) { logger().Debug(msg) }
+
+// Info writes an info line with the default logger.
+func Info(msg string) { logger().Info(msg) }
+
+// Warn writes a warning line with the default logger.
+func Warn(msg string) { logger().Warn(msg) }
+
+// Error writes an error line with the default logger.
+func Error(msg string) { logger().Error(msg) }
+
+// Debugf writes a formatted debug line with the default logger.
+func Debugf(format string, args ...interface{}) { logger().Debugf(format, args...) }
+
+// Infof writes a formatted info line with the default logger.
+func Infof(format string, args ...interface{}) { logger().Infof(format, args...) }
+
+// Warnf writes a formatted warning line with the default logger.
+func Warnf(format string, args ...interface{}) { logger().Warnf(format, args...) }
+
+// Errorf writes a formatted error line with the default logger.
+func Errorf(format string, args ...interface{}) { logger().Errorf(format, args...) }
@@ -0,0 +1,71 @@
+package ux
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+)
+
+func TestLoggerAlignsTags(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(&buf, nil)
+
+	log.Info("started")
+	log.Warnf("%d retries left", 2)
+	log.Error("failed\nsee details above")
+	log.Debug("done")
+
+	expected := strings.Join([]string{
+		"[INFO]  started",
+		"[WARN]  2 retries left",
+		"[ERROR] failed",
+		"        see details above",
+		"[DEBUG] done",
+		"",
+	}, "\n")
+	if got := core.StripANSI(buf.String()); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLoggerOtherLevels(t *testing.T) {
+	var buf bytes.Buffer
+	NewLogger(&buf, nil).Log(LogLevel(-1), "trace\nmore")
+
+	expected := "[LEVEL-1] trace\n          more\n"
+	if got := core.StripANSI(buf.String()); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(NewLogger(&buf, nil))
+	defer SetLogger(nil)
+
+	Warnf("%d left", 3)
+	if got := core.StripANSI(buf.String()); got != "[WARN]  3 left\n" {
+		t.Errorf("got %q", got)
+	}
+
+	// Replacing the logger while other goroutines log must not race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetLogger(NewLogger(io.Discard, nil))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		logger().Debug("tick")
+	}
+	<-done
+
+	SetLogger(nil)
+	if logger() != stdoutLogger {
+		t.Error("SetLogger(nil) should restore the default logger")
+	}
+}
@@ -0,0 +1,42 @@
+// Package ux provides the output sink shared by the package's helpers.
+package ux
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// output replaces os.Stdout as the destination of ux output; see SetOutput.
+var output io.Writer
+
+// SetOutput sets where spinners, progress bars, the pager, the bell and
+// effects draw, os.Stdout by default. SetOutput(io.Discard) turns them all
+// off, e.g. for a --no-ui flag or when cmdux is used from a library; the
+// package-level log functions are silenced separately with SetLogger. A nil
+// writer restores os.Stdout.
+//
+// Call it at startup: spinners and progress bars created before the call
+// still hide and show the cursor on the old writer.
+func SetOutput(w io.Writer) {
+	output = w
+	if w == nil {
+		w = os.Stdout
+	}
+	SetEffectOutput(w)
+}
+
+// out returns the writer ux output goes to.
+func out() io.Writer {
+	if output != nil {
+		return output
+	}
+	return os.Stdout
+}
+
+// outIsTerminal reports whether ux output goes to a terminal.
+func outIsTerminal() bool {
+	f, ok := out().(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
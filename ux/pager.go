@@ -0,0 +1,217 @@
+// Package ux provides an interactive pager for long output.
+package ux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// Page shows text one screenful at a time, like more(1), wrapped to the
+// terminal width. Space or PgDn advances a page, b or PgUp goes back one,
+// the arrow keys (or j/k and Enter) scroll by a line, g/G jump to the start
+// and end, / searches forward (n repeats the search), and q or Esc quits.
+// Advancing past the last page also quits. Ctrl-C returns core.ErrCancelled.
+//
+// When stdin or the output (see SetOutput) is not a terminal, or the text
+// fits on one screen, it is printed in full. A nil theme uses
+// style.DefaultTheme.
+func Page(text string, theme *style.Theme) error {
+	if theme == nil {
+		theme = style.DefaultTheme()
+	}
+	text = strings.TrimRight(text, "\n")
+	guard := core.NewTerminalGuard(os.Stdin)
+	if !guard.IsTerminal() || !outIsTerminal() {
+		fmt.Fprintln(out(), text)
+		return nil
+	}
+
+	width, height := core.GetTerminalSize()
+	p := newPager(core.WrapCells(text, width), height-2)
+	if len(p.lines) <= p.height {
+		fmt.Fprintln(out(), text)
+		return nil
+	}
+
+	if err := guard.Acquire(); err != nil {
+		return err
+	}
+	defer guard.Release()
+
+	keys := core.NewKeyReader(core.Stdin())
+	screen := &core.LiveRegion{Writer: out()}
+	statusColor := style.ColorOr(theme.Muted, style.Muted)
+	messageColor := style.ColorOr(theme.Warning, style.Warning)
+
+	for {
+		status := statusColor.Sprint(p.status())
+		switch {
+		case p.searching:
+			status = "/" + p.query
+		case p.message != "":
+			status = messageColor.Sprint(p.message)
+		}
+		screen.Draw(append(p.view(), status))
+
+		key, err := keys.ReadKey()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if key.Type == core.KeyCtrlC {
+			fmt.Fprint(out(), core.ClearLinesSeq(1))
+			return core.ErrCancelled
+		}
+		if p.handle(key) {
+			break
+		}
+	}
+
+	// Leave the last page on screen but drop the status line
+	fmt.Fprint(out(), core.ClearLinesSeq(1))
+	return nil
+}
+
+// pager holds the scrolling and search state of Page, apart from the
+// terminal, so it can be driven by key events in tests.
+type pager struct {
+	lines  []string
+	height int
+	top    int
+	match  int
+
+	searching bool
+	query     string
+	message   string
+}
+
+// newPager creates a pager over the wrapped lines showing height lines at a
+// time.
+func newPager(lines []string, height int) *pager {
+	if height < 1 {
+		height = 1
+	}
+	return &pager{lines: lines, height: height, match: -1}
+}
+
+// view returns the lines currently on screen.
+func (p *pager) view() []string {
+	end := p.top + p.height
+	if end > len(p.lines) {
+		end = len(p.lines)
+	}
+	return p.lines[p.top:end]
+}
+
+// status returns the status line, e.g. "--More-- (42%)" or "(END)".
+func (p *pager) status() string {
+	bottom := p.top + p.height
+	if bottom >= len(p.lines) {
+		return "(END)"
+	}
+	return fmt.Sprintf("--More-- (%d%%)", bottom*100/len(p.lines))
+}
+
+// lastTop returns the top line of the last page.
+func (p *pager) lastTop() int {
+	if last := len(p.lines) - p.height; last > 0 {
+		return last
+	}
+	return 0
+}
+
+// scrollTo moves the top line, keeping the page within the text.
+func (p *pager) scrollTo(top int) {
+	p.top = top
+	if p.top > p.lastTop() {
+		p.top = p.lastTop()
+	}
+	if p.top < 0 {
+		p.top = 0
+	}
+}
+
+// handle applies a key press and reports whether the pager should quit.
+func (p *pager) handle(key core.Key) bool {
+	if p.searching {
+		p.handleSearchKey(key)
+		return false
+	}
+	p.message = ""
+	if key.Type != core.KeyRune || key.Rune != 'n' {
+		p.match = -1
+	}
+
+	atEnd := p.top >= p.lastTop()
+	switch {
+	case key.Type == core.KeyRune && key.Rune == ' ' || key.Type == core.KeyPageDown || key.Type == core.KeyRune && key.Rune == 'f':
+		if atEnd {
+			return true
+		}
+		p.scrollTo(p.top + p.height)
+	case key.Type == core.KeyPageUp || key.Type == core.KeyRune && key.Rune == 'b':
+		p.scrollTo(p.top - p.height)
+	case key.Type == core.KeyDown || key.Type == core.KeyEnter || key.Type == core.KeyRune && key.Rune == 'j':
+		p.scrollTo(p.top + 1)
+	case key.Type == core.KeyUp || key.Type == core.KeyRune && key.Rune == 'k':
+		p.scrollTo(p.top - 1)
+	case key.Type == core.KeyHome || key.Type == core.KeyRune && key.Rune == 'g':
+		p.scrollTo(0)
+	case key.Type == core.KeyEnd || key.Type == core.KeyRune && key.Rune == 'G':
+		p.scrollTo(p.lastTop())
+	case key.Type == core.KeyRune && key.Rune == '/':
+		p.searching = true
+		p.query = ""
+	case key.Type == core.KeyRune && key.Rune == 'n':
+		p.search()
+	case key.Type == core.KeyRune && key.Rune == 'q' || key.Type == core.KeyEscape || key.Type == core.KeyCtrlD:
+		return true
+	}
+	return false
+}
+
+// handleSearchKey edits the search query typed after "/".
+func (p *pager) handleSearchKey(key core.Key) {
+	switch key.Type {
+	case core.KeyRune:
+		p.query += string(key.Rune)
+	case core.KeyBackspace:
+		if runes := []rune(p.query); len(runes) > 0 {
+			p.query = string(runes[:len(runes)-1])
+		}
+	case core.KeyEnter:
+		p.searching = false
+		p.search()
+	case core.KeyEscape, core.KeyCtrlC, core.KeyCtrlD:
+		p.searching = false
+	}
+}
+
+// search scrolls to the next line containing the query, ignoring case and
+// styling. It starts below the top line, or below the previous match when
+// repeating a search on the last page, which cannot scroll down to it.
+func (p *pager) search() {
+	if p.query == "" {
+		return
+	}
+	start := p.top
+	if p.match > start {
+		start = p.match
+	}
+	query := strings.ToLower(p.query)
+	for i := start + 1; i < len(p.lines); i++ {
+		if strings.Contains(strings.ToLower(core.StripANSI(p.lines[i])), query) {
+			p.match = i
+			p.scrollTo(i)
+			return
+		}
+	}
+	p.message = fmt.Sprintf("Pattern not found: %s", p.query)
+}
@@ -0,0 +1,67 @@
+package ux
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bagaking/cmdux/core"
+)
+
+func TestPagerNavigation(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 25; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	p := newPager(lines, 10)
+	space := core.Key{Type: core.KeyRune, Rune: ' '}
+
+	if got := p.status(); got != "--More-- (40%)" {
+		t.Errorf("status = %q, want --More-- (40%%)", got)
+	}
+	if p.handle(space) || p.view()[0] != "line 11" {
+		t.Fatalf("space should show the second page, top is %q", p.view()[0])
+	}
+	if p.handle(space) || p.view()[0] != "line 16" || p.status() != "(END)" {
+		t.Fatalf("last page should end the text, top is %q, status %q", p.view()[0], p.status())
+	}
+	if !p.handle(space) {
+		t.Error("space on the last page should quit")
+	}
+
+	p.handle(core.Key{Type: core.KeyRune, Rune: 'b'})
+	if p.view()[0] != "line 6" {
+		t.Errorf("b should go back a page, top is %q", p.view()[0])
+	}
+	p.handle(core.Key{Type: core.KeyHome})
+	if p.view()[0] != "line 1" {
+		t.Errorf("Home should go to the start, top is %q", p.view()[0])
+	}
+}
+
+func TestPagerSearch(t *testing.T) {
+	lines := []string{"alpha", "beta", "Gamma", "delta", "gamma ray", "epsilon", "zeta"}
+	p := newPager(lines, 3)
+
+	for _, key := range []core.Key{
+		{Type: core.KeyRune, Rune: '/'},
+		{Type: core.KeyRune, Rune: 'g'},
+		{Type: core.KeyRune, Rune: 'a'},
+		{Type: core.KeyRune, Rune: 'm'},
+		{Type: core.KeyEnter},
+	} {
+		p.handle(key)
+	}
+	if p.view()[0] != "Gamma" {
+		t.Fatalf("search should scroll to the first match, top is %q", p.view()[0])
+	}
+
+	// The second match starts the last page
+	p.handle(core.Key{Type: core.KeyRune, Rune: 'n'})
+	if p.top != 4 || p.match != 4 {
+		t.Fatalf("n should find the next match, top %d match %d", p.top, p.match)
+	}
+	p.handle(core.Key{Type: core.KeyRune, Rune: 'n'})
+	if p.message == "" {
+		t.Error("searching past the last match should report it")
+	}
+}
@@ -3,45 +3,103 @@ package ux
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
 )
 
+// progressLogInterval is the longest time between log lines in non-interactive mode.
+const progressLogInterval = 5 * time.Second
+
+// progressAnimation is how long an Animated bar takes to slide to a new value.
+// Updates arriving faster than this are drawn without animation.
+const progressAnimation = 150 * time.Millisecond
+
+// progressAnimationFPS is the frame rate of an Animated bar's transitions.
+const progressAnimationFPS = 60
+
 // ProgressBar represents a progress indicator.
 type ProgressBar struct {
 	*core.Component
-	current   int
-	total     int
-	width     int
-	prefix    string
-	suffix    string
-	completed bool
+	current     int
+	total       int
+	width       int
+	prefix      string
+	suffix      string
+	completed   bool
 	showPercent bool
 	showNumbers bool
+	numbers     func(current, total int) string
 	fillChar    string
 	emptyChar   string
 	leftCap     string
 	rightCap    string
 	color       *style.Color
 	bgColor     *style.Color
+	solid       bool
+	solidFill   *style.Color
+	solidEmpty  *style.Color
+	cursor      *core.CursorGuard
+	bell        bool
+
+	// Animated transitions, run on a goroutine; bumping animation cancels
+	// the running one
+	animated  bool
+	animation int
+	shown     int
+	lastDraw  time.Time
+
+	// Callbacks, run after mu is released so they may use the bar
+	mu           sync.Mutex
+	onProgress   func(pct float64)
+	onComplete   func()
+	lastPercent  int
+	notifiedDone bool
+
+	// Non-interactive (non-TTY) output state
+	interactive bool
+	lastLogStep int
+	lastLogTime time.Time
 }
 
-// NewProgressBar creates a new progress bar.
+// Default progress bar characters, with and without Unicode support.
+const (
+	progressFill       = "█"
+	progressEmpty      = "░"
+	asciiProgressFill  = "#"
+	asciiProgressEmpty = "-"
+)
+
+// NewProgressBar creates a new progress bar. It is drawn with block
+// characters, or with "#" and "-" where style.SupportsUnicode reports no
+// Unicode support; SetChars overrides either.
 func NewProgressBar(width int) *ProgressBar {
+	fillChar, emptyChar := progressFill, progressEmpty
+	if !style.SupportsUnicode() {
+		fillChar, emptyChar = asciiProgressFill, asciiProgressEmpty
+	}
+
 	return &ProgressBar{
 		Component:   core.NewComponent(),
 		width:       width,
 		prefix:      "Progress",
-		fillChar:    "█",
-		emptyChar:   "░",
+		fillChar:    fillChar,
+		emptyChar:   emptyChar,
 		leftCap:     "[",
 		rightCap:    "]",
 		showPercent: true,
 		showNumbers: true,
 		color:       style.Primary,
 		bgColor:     style.Muted,
+		solidFill:   style.PrimaryBg,
+		solidEmpty:  style.MutedBg,
+		interactive: outIsTerminal(),
+		lastLogStep: -1,
+		cursor:      core.NewCursorGuard(out()),
 	}
 }
 
@@ -75,6 +133,43 @@ func (pb *ProgressBar) ShowNumbers(show bool) *ProgressBar {
 	return pb
 }
 
+// NumberFormatter sets how ShowNumbers prints the current and total values
+// inside the parentheses, "current/total" by default. BytesFormatter suits
+// downloads; a closure can add a unit:
+//
+//	pb.NumberFormatter(func(current, total int) string {
+//		return fmt.Sprintf("%d/%d files", current, total)
+//	})
+//
+// A nil formatter restores the default.
+func (pb *ProgressBar) NumberFormatter(format func(current, total int) string) *ProgressBar {
+	pb.numbers = format
+	return pb
+}
+
+// BytesFormatter is a NumberFormatter for byte counts, printing e.g.
+// "1.2 MB/50.0 MB".
+func BytesFormatter(current, total int) string {
+	return FormatBytes(int64(current)) + "/" + FormatBytes(int64(total))
+}
+
+// FormatBytes returns a byte count in decimal (SI) units with one decimal
+// place, e.g. "512 B", "1.2 MB" or "3.5 GB".
+func FormatBytes(bytes int64) string {
+	const unit = 1000
+	if bytes < unit && bytes > -unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	prefixes := "kMGTPE"
+	i := 0
+	// Move up a unit before rounding would print e.g. "1000.0 kB"
+	for value /= unit; math.Abs(value) >= unit-0.05 && i < len(prefixes)-1; value /= unit {
+		i++
+	}
+	return fmt.Sprintf("%.1f %cB", value, prefixes[i])
+}
+
 // SetChars sets the characters used for the progress bar.
 func (pb *ProgressBar) SetChars(fill, empty, leftCap, rightCap string) *ProgressBar {
 	pb.fillChar = fill
@@ -96,70 +191,310 @@ func (pb *ProgressBar) BgColor(color *style.Color) *ProgressBar {
 	return pb
 }
 
-// Update updates the current progress value.
+// SolidFill switches between drawing the bar with the fill and empty glyphs
+// (the default) and drawing it as solid blocks of background color, set with
+// SolidColors. Solid bars need a color terminal to be visible.
+func (pb *ProgressBar) SolidFill(solid bool) *ProgressBar {
+	pb.solid = solid
+	return pb
+}
+
+// SolidColors sets the background colors of the filled and empty parts of a
+// SolidFill bar.
+func (pb *ProgressBar) SolidColors(fill, empty *style.Color) *ProgressBar {
+	pb.solidFill = fill
+	pb.solidEmpty = empty
+	return pb
+}
+
+// Interactive overrides whether the bar redraws in place (true) or prints
+// newline-terminated log lines (false). By default it is detected from
+// whether stdout is a terminal, so CI logs stay readable.
+func (pb *ProgressBar) Interactive(interactive bool) *ProgressBar {
+	pb.interactive = interactive
+	return pb
+}
+
+// BellOnComplete rings the terminal bell when Complete is called, for long
+// tasks the user may have switched away from. See SetQuiet.
+func (pb *ProgressBar) BellOnComplete(enabled bool) *ProgressBar {
+	pb.bell = enabled
+	return pb
+}
+
+// Animated makes Update slide the fill from the previously drawn value to the
+// new one over a few frames instead of jumping, so chunky updates look
+// smoother. The transition runs in the background for at most 150ms and is
+// cut short by the next update, so animation never holds up the caller;
+// updates arriving faster than that are drawn immediately. It only applies
+// in interactive mode.
+func (pb *ProgressBar) Animated(animated bool) *ProgressBar {
+	pb.animated = animated
+	return pb
+}
+
+// OnProgress sets a function called from Update, Increment, IncrementBy and
+// Complete whenever the percentage crosses an integer boundary, e.g. to log a
+// line at 25, 50 and 75%. It runs on the caller's goroutine after the bar's
+// lock is released, so it may call the bar's methods.
+func (pb *ProgressBar) OnProgress(fn func(pct float64)) *ProgressBar {
+	pb.onProgress = fn
+	return pb
+}
+
+// OnComplete sets a function called once the bar reaches its total, through
+// Update, Increment, IncrementBy or Complete. It is called again if the
+// progress drops below the total and reaches it anew.
+func (pb *ProgressBar) OnComplete(fn func()) *ProgressBar {
+	pb.onComplete = fn
+	return pb
+}
+
+// Update updates the current progress value. The value may go down as well as
+// up, e.g. when a work queue grows; dropping below the total clears the
+// completed flag. In non-interactive mode a log line is printed only every 10%
+// or every few seconds.
 func (pb *ProgressBar) Update(current int) {
+	pb.mu.Lock()
 	pb.current = current
-	fmt.Print("\r" + pb.Render())
+	if pb.current < pb.total {
+		pb.completed = false
+	}
+	if !pb.interactive {
+		pb.logLine(false)
+	} else {
+		pb.cursor.Hide()
+		pb.animation++
+		if !pb.animate() {
+			pb.draw(pb.current)
+		}
+	}
+	notify := pb.dueCallbacks()
+	pb.mu.Unlock()
+
+	notify()
 }
 
 // Complete marks the progress as complete and shows a completion message.
 func (pb *ProgressBar) Complete(message string) {
+	pb.mu.Lock()
 	pb.current = pb.total
 	pb.completed = true
-	fmt.Print("\r" + pb.Render())
-	if message != "" {
-		fmt.Printf("\n%s %s\n", style.Success.Sprint("✓"), message)
+	if !pb.interactive {
+		pb.logLine(true)
+		if message != "" {
+			fmt.Fprintf(out(), "%s %s\n", style.Success.Sprint("✓"), message)
+		}
 	} else {
-		fmt.Println()
+		pb.animation++
+		pb.draw(pb.current)
+		pb.cursor.Show()
+		if message != "" {
+			fmt.Fprintf(out(), "\n%s %s\n", style.Success.Sprint("✓"), message)
+		} else {
+			fmt.Fprintln(out())
+		}
 	}
+	if pb.bell {
+		Bell()
+	}
+	notify := pb.dueCallbacks()
+	pb.mu.Unlock()
+
+	notify()
+}
+
+// draw redraws the bar in place showing the given value. The rest of the
+// line is erased, since a lower value can render shorter than the last one.
+func (pb *ProgressBar) draw(value int) {
+	fmt.Fprint(out(), "\r"+pb.render(value)+"\033[K")
+	pb.shown = value
+	pb.lastDraw = time.Now()
+}
+
+// animate starts an Animated bar sliding from the value on screen towards the
+// current one on a goroutine, ending with the current value, and reports
+// whether it did; if not, the caller draws the value at once. Bumping
+// pb.animation stops the transition. Call it with mu held.
+func (pb *ProgressBar) animate() bool {
+	from, to := pb.shown, pb.current
+	if !pb.animated || pb.total <= 0 || from == to || pb.lastDraw.IsZero() || time.Since(pb.lastDraw) < progressAnimation {
+		return false
+	}
+
+	generation := pb.animation
+	drawFrame := func(value int) {
+		pb.mu.Lock()
+		defer pb.mu.Unlock()
+		if pb.animation == generation {
+			pb.draw(value)
+		}
+	}
+
+	go func() {
+		animator := NewAnimator(progressAnimationFPS)
+		frames := int(progressAnimation * time.Duration(animator.FPS()) / time.Second)
+		animator.Run(progressAnimation, func(frame int) {
+			if frame+1 < frames {
+				drawFrame(from + (to-from)*(frame+1)/frames)
+			}
+		})
+		drawFrame(to)
+	}()
+	return true
+}
+
+// dueCallbacks records the current progress and returns a function running
+// the callbacks it triggers. Call it with mu held and the result without.
+func (pb *ProgressBar) dueCallbacks() func() {
+	var due []func()
+
+	pct := pb.GetPercentage()
+	if step := int(pct); step != pb.lastPercent {
+		pb.lastPercent = step
+		if onProgress := pb.onProgress; onProgress != nil {
+			due = append(due, func() { onProgress(pct) })
+		}
+	}
+
+	done := pb.total > 0 && pb.current >= pb.total
+	if done && !pb.notifiedDone && pb.onComplete != nil {
+		due = append(due, pb.onComplete)
+	}
+	pb.notifiedDone = done
+
+	return func() {
+		for _, fn := range due {
+			fn()
+		}
+	}
+}
+
+// logLine prints a plain progress line such as "Installing: 40% (40/100)",
+// rate-limited to 10% steps or progressLogInterval unless forced.
+func (pb *ProgressBar) logLine(force bool) {
+	step := int(pb.GetPercentage() / 10)
+	if !force && step == pb.lastLogStep && time.Since(pb.lastLogTime) < progressLogInterval {
+		return
+	}
+	pb.lastLogStep = step
+	pb.lastLogTime = time.Now()
+
+	var line strings.Builder
+	if pb.prefix != "" {
+		line.WriteString(pb.prefix + ":")
+	}
+	if pb.total == 0 {
+		line.WriteString(fmt.Sprintf(" %d", pb.current))
+	} else {
+		if pb.showPercent {
+			line.WriteString(fmt.Sprintf(" %.0f%%", pb.GetPercentage()))
+		}
+		if pb.showNumbers {
+			line.WriteString(" (" + pb.formatNumbers(pb.current) + ")")
+		}
+	}
+	if pb.suffix != "" {
+		line.WriteString(" " + pb.suffix)
+	}
+
+	fmt.Fprintln(out(), strings.TrimSpace(line.String()))
+}
+
+// formatNumbers returns the numbers ShowNumbers prints for value.
+func (pb *ProgressBar) formatNumbers(value int) string {
+	if pb.numbers != nil {
+		return pb.numbers(value, pb.total)
+	}
+	return fmt.Sprintf("%d/%d", value, pb.total)
 }
 
 // Render renders the progress bar as a string.
 func (pb *ProgressBar) Render() string {
+	return pb.render(pb.current)
+}
+
+// RenderContext renders the progress bar under ctx, e.g. an app's
+// RenderContext. With a symbol set whose box glyphs differ from the default
+// ones, such as style.ASCIISymbols, block characters left at their defaults
+// become "#" and "-".
+func (pb *ProgressBar) RenderContext(ctx core.RenderContext) string {
+	fillChar, emptyChar := pb.fillChar, pb.emptyChar
+	ascii := ctx.Symbols.BoxHorizontal != "" && ctx.Symbols.BoxHorizontal != style.BoxHorizontal
+	if ascii && fillChar == progressFill && emptyChar == progressEmpty {
+		fillChar, emptyChar = asciiProgressFill, asciiProgressEmpty
+	}
+	return pb.renderChars(pb.current, fillChar, emptyChar)
+}
+
+// render renders the progress bar as if its current value were value.
+func (pb *ProgressBar) render(value int) string {
+	return pb.renderChars(value, pb.fillChar, pb.emptyChar)
+}
+
+// renderChars renders the progress bar as if its current value were value,
+// filling it with fillChar and emptyChar.
+func (pb *ProgressBar) renderChars(value int, fillChar, emptyChar string) string {
 	if pb.total == 0 {
 		return pb.prefix + " [indeterminate]"
 	}
 
-	percentage := float64(pb.current) / float64(pb.total) * 100
-	filledWidth := int(float64(pb.width) * float64(pb.current) / float64(pb.total))
-	emptyWidth := pb.width - filledWidth
+	width := pb.width
+	if width < 0 {
+		width = 0
+	}
+
+	percentage := pb.percentOf(value)
+	filledWidth := int(float64(width) * percentage / 100)
+	if filledWidth < 0 {
+		filledWidth = 0
+	} else if filledWidth > width {
+		filledWidth = width
+	}
+	emptyWidth := width - filledWidth
 
 	// Build the progress bar
+	fillColor, emptyColor := pb.color, pb.bgColor
+	if pb.solid {
+		fillChar, emptyChar = " ", " "
+		fillColor, emptyColor = pb.solidFill, pb.solidEmpty
+	}
+
 	var bar strings.Builder
-	
+
 	// Add filled portion
 	if filledWidth > 0 {
-		bar.WriteString(pb.color.Sprint(strings.Repeat(pb.fillChar, filledWidth)))
+		bar.WriteString(fillColor.Sprint(strings.Repeat(fillChar, filledWidth)))
 	}
-	
+
 	// Add empty portion
 	if emptyWidth > 0 {
-		bar.WriteString(pb.bgColor.Sprint(strings.Repeat(pb.emptyChar, emptyWidth)))
+		bar.WriteString(emptyColor.Sprint(strings.Repeat(emptyChar, emptyWidth)))
 	}
 
 	// Build the complete display
 	var result strings.Builder
-	
+
 	// Prefix
 	if pb.prefix != "" {
 		result.WriteString(pb.prefix + " ")
 	}
-	
+
 	// Progress bar with caps
 	result.WriteString(pb.leftCap)
 	result.WriteString(bar.String())
 	result.WriteString(pb.rightCap)
-	
+
 	// Percentage
 	if pb.showPercent {
 		result.WriteString(fmt.Sprintf(" %.1f%%", percentage))
 	}
-	
+
 	// Numbers
 	if pb.showNumbers {
-		result.WriteString(fmt.Sprintf(" (%d/%d)", pb.current, pb.total))
+		result.WriteString(" (" + pb.formatNumbers(value) + ")")
 	}
-	
+
 	// Suffix
 	if pb.suffix != "" {
 		result.WriteString(" " + pb.suffix)
@@ -184,12 +519,41 @@ func (pb *ProgressBar) GetTotal() int {
 	return pb.total
 }
 
-// GetPercentage returns the current percentage (0-100).
+// GetPercentage returns the current percentage, clamped to 0-100.
 func (pb *ProgressBar) GetPercentage() float64 {
-	if pb.total == 0 {
+	return pb.percentOf(pb.current)
+}
+
+// percentOf returns value as a percentage of the total, clamped to 0-100.
+func (pb *ProgressBar) percentOf(value int) float64 {
+	if pb.total <= 0 {
 		return 0
 	}
-	return float64(pb.current) / float64(pb.total) * 100
+	percentage := float64(value) / float64(pb.total) * 100
+	return math.Max(0, math.Min(100, percentage))
+}
+
+// Reset sets the current value back to 0 and clears the completed flag, e.g.
+// before retrying an operation. An interactive bar still on screen is redrawn
+// empty. Reset shows the cursor again if an update hid it; the next update
+// hides it.
+func (pb *ProgressBar) Reset() *ProgressBar {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	pb.animation++
+	if pb.interactive && !pb.lastDraw.IsZero() && !pb.completed {
+		pb.draw(0)
+	}
+	pb.cursor.Show()
+	pb.current = 0
+	pb.completed = false
+	pb.lastPercent = 0
+	pb.notifiedDone = false
+	pb.lastLogStep = -1
+	pb.lastLogTime = time.Time{}
+	pb.shown = 0
+	return pb
 }
 
 // IsComplete returns true if the progress is complete.
@@ -199,16 +563,18 @@ func (pb *ProgressBar) IsComplete() bool {
 
 // Increment increments the current progress by 1.
 func (pb *ProgressBar) Increment() {
-	pb.current++
-	if pb.current > pb.total {
-		pb.current = pb.total
-	}
+	pb.IncrementBy(1)
 }
 
 // IncrementBy increments the current progress by the specified amount.
 func (pb *ProgressBar) IncrementBy(amount int) {
+	pb.mu.Lock()
 	pb.current += amount
 	if pb.current > pb.total {
 		pb.current = pb.total
 	}
-}
\ No newline at end of file
+	notify := pb.dueCallbacks()
+	pb.mu.Unlock()
+
+	notify()
+}
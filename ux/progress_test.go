@@ -0,0 +1,279 @@
+package ux
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagaking/cmdux/core"
+	"github.com/bagaking/cmdux/style"
+)
+
+// withUnicode runs the test with style.SupportsUnicode forced to enabled.
+func withUnicode(t *testing.T, enabled bool) {
+	t.Helper()
+	previous := style.SupportsUnicode()
+	style.SetUnicodeSupport(enabled)
+	t.Cleanup(func() { style.SetUnicodeSupport(previous) })
+}
+
+func TestProgressBarRenderClamps(t *testing.T) {
+	withUnicode(t, true)
+	tests := []struct {
+		name    string
+		width   int
+		current int
+		filled  int
+		empty   int
+		percent string
+	}{
+		{"over total", 10, 150, 10, 0, "100.0%"},
+		{"negative current", 10, -5, 0, 10, "0.0%"},
+		{"zero width", 0, 50, 0, 0, "50.0%"},
+		{"negative width", -3, 50, 0, 0, "50.0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb := NewProgressBar(tt.width).SetTotal(100).SetCurrent(tt.current)
+
+			output := core.StripANSI(pb.Render())
+			if got := strings.Count(output, "█"); got != tt.filled {
+				t.Errorf("filled cells = %d, want %d (%q)", got, tt.filled, output)
+			}
+			if got := strings.Count(output, "░"); got != tt.empty {
+				t.Errorf("empty cells = %d, want %d (%q)", got, tt.empty, output)
+			}
+			if !strings.Contains(output, tt.percent) {
+				t.Errorf("output %q does not contain %q", output, tt.percent)
+			}
+		})
+	}
+}
+
+func TestProgressBarReset(t *testing.T) {
+	pb := NewProgressBar(10).SetTotal(4).Interactive(false)
+	pb.current = 4
+	pb.completed = true
+
+	pb.Reset()
+	if pb.GetCurrent() != 0 || pb.IsComplete() {
+		t.Errorf("after Reset current = %d, complete = %v", pb.GetCurrent(), pb.IsComplete())
+	}
+
+	// An interrupted interactive bar must not leave the cursor hidden
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+	pb = NewProgressBar(10).SetTotal(4).Interactive(true)
+	pb.Update(2)
+	pb.Reset()
+	output := buf.String()
+	if !strings.Contains(output, core.HideCursorSeq) || !strings.HasSuffix(output, core.ShowCursorSeq) {
+		t.Errorf("expected Reset to show the hidden cursor, got %q", output)
+	}
+	if !strings.Contains(core.StripANSI(output), "(0/4)") {
+		t.Errorf("expected Reset to redraw the bar empty, got %q", output)
+	}
+}
+
+func TestProgressBarDecrease(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	pb := NewProgressBar(10).SetTotal(100).Interactive(true)
+	pb.cursor = core.NewCursorGuard(io.Discard)
+	pb.Update(100)
+	buf.Reset()
+	pb.Update(5)
+
+	// The shorter line must not leave "100)" residue behind it
+	output := buf.String()
+	if !strings.HasSuffix(output, "\033[K") || !strings.Contains(core.StripANSI(output), "(5/100)") {
+		t.Errorf("expected the redraw to erase the rest of the line, got %q", output)
+	}
+}
+
+func TestProgressBarCallbacks(t *testing.T) {
+	var reported []float64
+	completed := 0
+
+	pb := NewProgressBar(10).SetTotal(200).Interactive(false)
+	pb.OnProgress(func(pct float64) {
+		reported = append(reported, pct)
+		_ = pb.GetPercentage() // callbacks may use the bar without deadlocking
+	}).OnComplete(func() { completed++ })
+
+	pb.IncrementBy(1) // 0.5%: no integer boundary crossed
+	pb.Update(50)
+	pb.Update(51) // 25.5%: same integer percent
+	pb.Increment()
+	pb.Update(200)
+	pb.Update(200)
+	pb.Complete("")
+
+	expected := []float64{25, 26, 100}
+	if len(reported) != len(expected) {
+		t.Fatalf("reported %v, want %v", reported, expected)
+	}
+	for i := range expected {
+		if reported[i] != expected[i] {
+			t.Errorf("reported %v, want %v", reported, expected)
+		}
+	}
+	if completed != 1 {
+		t.Errorf("OnComplete called %d times, want 1", completed)
+	}
+
+	pb.Update(100)
+	pb.Update(200)
+	if completed != 2 {
+		t.Errorf("expected OnComplete again after dropping below the total, got %d calls", completed)
+	}
+}
+
+func TestProgressBarAnimated(t *testing.T) {
+	pb := NewProgressBar(20).SetTotal(100).Interactive(true).Animated(true).ShowPercent(false)
+	pb.cursor = core.NewCursorGuard(io.Discard)
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	// frames runs update, waits for any transition to end and returns the
+	// frames drawn
+	frames := func(update func()) []string {
+		t.Helper()
+		pb.mu.Lock()
+		buf.Reset()
+		pb.mu.Unlock()
+
+		update()
+		for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+			pb.mu.Lock()
+			if pb.shown == pb.current {
+				output := buf.String()
+				pb.mu.Unlock()
+				return strings.Split(strings.TrimPrefix(output, "\r"), "\r")
+			}
+			pb.mu.Unlock()
+			if time.Now().After(deadline) {
+				t.Fatal("the transition did not finish")
+			}
+		}
+	}
+
+	frames(func() { pb.Update(10) })
+	time.Sleep(progressAnimation)
+
+	// The transition runs in the background
+	slid := frames(func() {
+		start := time.Now()
+		pb.Update(80)
+		if elapsed := time.Since(start); elapsed > progressAnimation/2 {
+			t.Errorf("Update blocked for %v while animating", elapsed)
+		}
+	})
+	if len(slid) < 3 {
+		t.Fatalf("expected intermediate frames, got %q", slid)
+	}
+	if !strings.Contains(slid[len(slid)/2], "/100)") || strings.Contains(slid[len(slid)/2], "(80/100)") {
+		t.Errorf("middle frame should show an intermediate value: %q", slid[len(slid)/2])
+	}
+	if last := slid[len(slid)-1]; !strings.Contains(last, "(80/100)") {
+		t.Errorf("last frame = %q, want the new value", last)
+	}
+
+	// Updates arriving faster than the animation are drawn at once
+	if snapped := frames(func() { pb.Update(90) }); len(snapped) != 1 {
+		t.Errorf("fast update drew %d frames, want 1", len(snapped))
+	}
+
+	// A newer value cuts a running transition short
+	time.Sleep(progressAnimation)
+	cut := frames(func() {
+		pb.Update(20)
+		pb.Update(30)
+	})
+	if last := cut[len(cut)-1]; !strings.Contains(last, "(30/100)") {
+		t.Errorf("last frame = %q, want the newest value", last)
+	}
+	for _, frame := range cut {
+		if strings.Contains(frame, "(20/100)") {
+			t.Errorf("the cancelled transition drew its target: %q", cut)
+		}
+	}
+}
+
+func TestProgressBarASCIIFallback(t *testing.T) {
+	withUnicode(t, false)
+
+	output := core.StripANSI(NewProgressBar(10).SetTotal(100).SetCurrent(30).Render())
+	if !strings.Contains(output, "[###-------]") {
+		t.Errorf("expected an ASCII bar without Unicode support, got %q", output)
+	}
+
+	output = core.StripANSI(NewProgressBar(4).SetChars("=", ".", "<", ">").SetTotal(4).SetCurrent(2).Render())
+	if !strings.Contains(output, "<==..>") {
+		t.Errorf("SetChars should override the defaults, got %q", output)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:          "0 B",
+		999:        "999 B",
+		1000:       "1.0 kB",
+		1234567:    "1.2 MB",
+		999960:     "1.0 MB",
+		50000000:   "50.0 MB",
+		3500000000: "3.5 GB",
+		-2048:      "-2.0 kB",
+		1 << 62:    "4.6 EB",
+	}
+	for bytes, expected := range tests {
+		if got := FormatBytes(bytes); got != expected {
+			t.Errorf("FormatBytes(%d) = %q, want %q", bytes, got, expected)
+		}
+	}
+}
+
+func TestProgressBarNumberFormatter(t *testing.T) {
+	pb := NewProgressBar(10).SetTotal(50000000).SetCurrent(1234567).NumberFormatter(BytesFormatter)
+	if output := core.StripANSI(pb.Render()); !strings.HasSuffix(output, "(1.2 MB/50.0 MB)") {
+		t.Errorf("expected byte counts, got %q", output)
+	}
+
+	pb = NewProgressBar(10).SetTotal(10).SetCurrent(3).NumberFormatter(func(current, total int) string {
+		return fmt.Sprintf("%d/%d files", current, total)
+	})
+	if output := core.StripANSI(pb.Render()); !strings.HasSuffix(output, "(3/10 files)") {
+		t.Errorf("expected the custom format, got %q", output)
+	}
+
+	pb.NumberFormatter(nil)
+	if output := core.StripANSI(pb.Render()); !strings.HasSuffix(output, "(3/10)") {
+		t.Errorf("a nil formatter should restore the default, got %q", output)
+	}
+}
+
+func TestProgressBarRenderContext(t *testing.T) {
+	withUnicode(t, true)
+	pb := NewProgressBar(4).SetTotal(4).SetCurrent(2)
+
+	ctx := core.NewRenderContext(style.DefaultTheme())
+	if output := core.StripANSI(pb.RenderContext(ctx)); !strings.Contains(output, "[██░░]") {
+		t.Errorf("default symbols should keep block characters, got %q", output)
+	}
+	ctx.Symbols = style.ASCIISymbols()
+	if output := core.StripANSI(pb.RenderContext(ctx)); !strings.Contains(output, "[##--]") {
+		t.Errorf("expected ASCII characters, got %q", output)
+	}
+	if output := core.StripANSI(pb.SetChars("=", ".", "<", ">").RenderContext(ctx)); !strings.Contains(output, "<==..>") {
+		t.Errorf("SetChars should be kept, got %q", output)
+	}
+}
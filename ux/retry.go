@@ -0,0 +1,76 @@
+// Package ux provides retrying of flaky operations with a spinner.
+package ux
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bagaking/cmdux/style"
+)
+
+// defaultRetryBackoff is the wait before the first retry when none is set.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// RetryOptions configures WithRetry. The zero value is ready to use.
+type RetryOptions struct {
+	// Message describes the operation, e.g. "Fetching releases". Defaults to "Working".
+	Message string
+	// Backoff is the wait before the first retry; it doubles after each
+	// failed attempt. Defaults to 500ms.
+	Backoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Retryable reports whether an error is worth retrying. Errors it
+	// rejects are returned at once. Nil retries every error.
+	Retryable func(error) bool
+	// Theme colors the spinner. Defaults to the theme set with SetEffectTheme.
+	Theme *style.Theme
+}
+
+// WithRetry runs fn up to attempts times while showing a spinner. After a
+// failure the spinner reads "retrying (2/3)..." until the next attempt, which
+// follows an exponential backoff. It finishes with a success line, or with a
+// persistent error line and the last error once the attempts run out:
+//
+//	err := ux.WithRetry(3, fetch, ux.RetryOptions{Message: "Fetching releases"})
+func WithRetry(attempts int, fn func() error, opts RetryOptions) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	message := opts.Message
+	if message == "" {
+		message = "Working"
+	}
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	theme := opts.Theme
+	if theme == nil {
+		theme = currentEffectTheme()
+	}
+
+	spinner := NewSpinner(SpinnerDots).Color(style.ColorOr(theme.Primary, style.Primary))
+	spinner.Start(message)
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			spinner.Success(message)
+			return nil
+		}
+		if attempt >= attempts || (opts.Retryable != nil && !opts.Retryable(err)) {
+			spinner.Error(fmt.Sprintf("%s: %v", message, err))
+			return err
+		}
+
+		spinner.Update(fmt.Sprintf("%s %s", message,
+			style.ColorOr(theme.Warning, style.Warning).Sprintf("retrying (%d/%d)...", attempt+1, attempts)))
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if opts.MaxBackoff > 0 && backoff > opts.MaxBackoff {
+			backoff = opts.MaxBackoff
+		}
+	}
+}
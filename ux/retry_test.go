@@ -0,0 +1,47 @@
+package ux
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	errFlaky := errors.New("connection reset")
+	errFatal := errors.New("unauthorized")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		failures  []error
+		retryable func(error) bool
+		calls     int
+		err       error
+	}{
+		{"succeeds first time", 3, nil, nil, 1, nil},
+		{"succeeds after retries", 3, []error{errFlaky, errFlaky}, nil, 3, nil},
+		{"runs out of attempts", 3, []error{errFlaky, errFlaky, errFlaky, errFlaky}, nil, 3, errFlaky},
+		{"stops on non-retryable error", 3, []error{errFlaky, errFatal}, func(err error) bool { return err == errFlaky }, 2, errFatal},
+		{"runs at least once", 0, []error{errFlaky}, nil, 1, errFlaky},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := WithRetry(tt.attempts, func() error {
+				calls++
+				if calls <= len(tt.failures) {
+					return tt.failures[calls-1]
+				}
+				return nil
+			}, RetryOptions{Backoff: time.Millisecond, Retryable: tt.retryable})
+
+			if err != tt.err {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if calls != tt.calls {
+				t.Errorf("expected %d calls, got %d", tt.calls, calls)
+			}
+		})
+	}
+}
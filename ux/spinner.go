@@ -2,11 +2,14 @@
 package ux
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
-	"unicode/utf8"
 
+	"github.com/bagaking/cmdux/core"
 	"github.com/bagaking/cmdux/style"
 )
 
@@ -17,32 +20,53 @@ type Spinner struct {
 	stop   chan bool
 	text   string
 	delay  time.Duration
+	cursor *core.CursorGuard
+	bell   bool
+
+	template func(frame, text string, elapsed time.Duration) string
+	started  time.Time
+
+	mu        sync.Mutex
+	drawn     bool
+	stopped   bool
+	lineWidth int // Display width of the last drawn line
 }
 
 // SpinnerStyle represents different spinner animation styles.
 type SpinnerStyle string
 
 const (
-	SpinnerDots    SpinnerStyle = "dots"
-	SpinnerCircle  SpinnerStyle = "circle"
-	SpinnerArrows  SpinnerStyle = "arrows"
-	SpinnerBounce  SpinnerStyle = "bounce"
-	SpinnerPulse   SpinnerStyle = "pulse"
-	SpinnerBlocks  SpinnerStyle = "blocks"
-	SpinnerWaves   SpinnerStyle = "waves"
-	SpinnerMatrix  SpinnerStyle = "matrix"
+	SpinnerDots   SpinnerStyle = "dots"
+	SpinnerCircle SpinnerStyle = "circle"
+	SpinnerArrows SpinnerStyle = "arrows"
+	SpinnerBounce SpinnerStyle = "bounce"
+	SpinnerPulse  SpinnerStyle = "pulse"
+	SpinnerBlocks SpinnerStyle = "blocks"
+	SpinnerWaves  SpinnerStyle = "waves"
+	SpinnerMatrix SpinnerStyle = "matrix"
 )
 
 // Animation frames for different spinner styles
 var spinnerFrames = map[SpinnerStyle][]string{
-	SpinnerDots:    {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
-	SpinnerCircle:  {"◐", "◓", "◑", "◒"},
-	SpinnerArrows:  {"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"},
-	SpinnerBounce:  {"⠁", "⠂", "⠄", "⠂"},
-	SpinnerPulse:   {"▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃"},
-	SpinnerBlocks:  {"▖", "▘", "▝", "▗"},
-	SpinnerWaves:   {"▂", "▄", "▅", "▆", "▇", "▆", "▅", "▄"},
-	SpinnerMatrix:  {"ｦ", "ｧ", "ｨ", "ｩ", "ｪ", "ｫ", "ｬ", "ｭ", "ｮ", "ｯ"},
+	SpinnerDots:   {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	SpinnerCircle: {"◐", "◓", "◑", "◒"},
+	SpinnerArrows: {"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"},
+	SpinnerBounce: {"⠁", "⠂", "⠄", "⠂"},
+	SpinnerPulse:  {"▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃"},
+	SpinnerBlocks: {"▖", "▘", "▝", "▗"},
+	SpinnerWaves:  {"▂", "▄", "▅", "▆", "▇", "▆", "▅", "▄"},
+	SpinnerMatrix: {"ｦ", "ｧ", "ｨ", "ｩ", "ｪ", "ｫ", "ｬ", "ｭ", "ｮ", "ｯ"},
+}
+
+// AvailableSpinners returns every spinner style in alphabetical order, e.g.
+// to list them in a --spinner flag's help or to validate the user's choice.
+func AvailableSpinners() []SpinnerStyle {
+	styles := make([]SpinnerStyle, 0, len(spinnerFrames))
+	for spinnerStyle := range spinnerFrames {
+		styles = append(styles, spinnerStyle)
+	}
+	sort.Slice(styles, func(i, j int) bool { return styles[i] < styles[j] })
+	return styles
 }
 
 // NewSpinner creates a new spinner with the specified style.
@@ -57,6 +81,7 @@ func NewSpinner(spinnerStyle SpinnerStyle) *Spinner {
 		color:  style.Primary,
 		stop:   make(chan bool),
 		delay:  100 * time.Millisecond,
+		cursor: core.NewCursorGuard(out()),
 	}
 }
 
@@ -72,58 +97,217 @@ func (s *Spinner) Delay(delay time.Duration) *Spinner {
 	return s
 }
 
+// BellOnComplete rings the terminal bell when Success is called, for long
+// tasks the user may have switched away from. The steps finished by Next do
+// not ring it. See SetQuiet.
+func (s *Spinner) BellOnComplete(enabled bool) *Spinner {
+	s.bell = enabled
+	return s
+}
+
+// Template sets the function that lays out each animated line from the
+// current frame (already colored), the text and the time since the spinner
+// was started. The default is frame + " " + text. For example, to put the
+// frame last and show the elapsed seconds:
+//
+//	s.Template(func(frame, text string, elapsed time.Duration) string {
+//		return fmt.Sprintf("%s %s %ds", text, frame, int(elapsed.Seconds()))
+//	})
+func (s *Spinner) Template(template func(frame, text string, elapsed time.Duration) string) *Spinner {
+	s.mu.Lock()
+	s.template = template
+	s.mu.Unlock()
+	return s
+}
+
 // Start starts the spinner animation with the given text.
 func (s *Spinner) Start(text string) {
+	s.StartAfter(0, text)
+}
+
+// StartAfter starts the spinner animation only if it is still running after
+// the given delay. If the spinner is stopped before then (via Stop, Success,
+// Error, ...), the animation is never drawn, which avoids a flash for
+// operations that finish quickly. A stopped spinner can be started again.
+func (s *Spinner) StartAfter(delay time.Duration, text string) {
+	s.start(context.Background(), delay, text)
+}
+
+// StartContext starts the spinner animation like Start, and stops it and
+// clears its line as soon as ctx is cancelled, without a call to Stop. A
+// single cancellation can so tear down every animation of an operation,
+// along with prompts run with RunContext.
+func (s *Spinner) StartContext(ctx context.Context, text string) {
+	s.start(ctx, 0, text)
+}
+
+// start begins a run that draws its first frame after delay and ends when
+// the spinner is stopped or ctx is done.
+func (s *Spinner) start(ctx context.Context, delay time.Duration, text string) {
+	s.mu.Lock()
 	s.text = text
+	s.started = time.Now()
+	if s.stopped {
+		// Each run gets its own stop channel, so a goroutine left over from
+		// a previous run can never draw into this one
+		s.stop = make(chan bool)
+		s.stopped = false
+		s.drawn = false
+		s.lineWidth = 0
+	}
+	stop := s.stop
+	s.mu.Unlock()
+
 	go func() {
-		i := 0
-		for {
+		done := ctx.Done()
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
 			select {
-			case <-s.stop:
+			case <-stop:
+				return
+			case <-done:
+				s.stopRun(stop)
 				return
-			default:
-				frame := s.frames[i%len(s.frames)]
-				fmt.Printf("\r%s %s", s.color.Sprint(frame), s.text)
-				time.Sleep(s.delay)
-				i++
+			case <-timer.C:
+			}
+		}
+
+		for i := 0; ; i++ {
+			if !s.drawFrame(stop, s.frames[i%len(s.frames)]) {
+				return
+			}
+			select {
+			case <-stop:
+				return
+			case <-done:
+				s.stopRun(stop)
+				return
+			case <-time.After(s.delay):
 			}
 		}
 	}()
 }
 
+// drawFrame prints a single frame for the run owning stop. It reports false
+// once that run has been stopped, so a frame can never be drawn after Stop
+// cleared the line.
+func (s *Spinner) drawFrame(stop chan bool, frame string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped || s.stop != stop {
+		return false
+	}
+
+	line := s.color.Sprint(frame) + " " + s.text
+	if s.template != nil {
+		line = s.template(s.color.Sprint(frame), s.text, time.Since(s.started))
+	}
+
+	// Frames and text vary in display width, so blank out any residue
+	// left by a wider previous line
+	width := core.MeasureText(line)
+	padding := ""
+	if width < s.lineWidth {
+		padding = strings.Repeat(" ", s.lineWidth-width)
+	}
+
+	if !s.drawn {
+		s.cursor.Hide()
+	}
+	fmt.Fprintf(out(), "\r%s%s", line, padding)
+	s.drawn = true
+	s.lineWidth = width
+	return true
+}
+
 // Stop stops the spinner animation and clears the line.
 func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+}
+
+// stopRun stops the run owning stop, if the spinner has not been stopped
+// and restarted since.
+func (s *Spinner) stopRun(stop chan bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == stop {
+		s.stopLocked()
+	}
+}
+
+// stopLocked stops the current run and clears its line. s.mu must be held.
+func (s *Spinner) stopLocked() {
+	if s.stopped {
+		return
+	}
+	s.stopped = true
 	close(s.stop)
-	fmt.Print("\r")
-	fmt.Print(strings.Repeat(" ", utf8.RuneCountInString(s.text)+3))
-	fmt.Print("\r")
+
+	if !s.drawn {
+		return
+	}
+	fmt.Fprint(out(), "\r")
+	fmt.Fprint(out(), strings.Repeat(" ", s.lineWidth))
+	fmt.Fprint(out(), "\r")
+	s.cursor.Show()
 }
 
 // Success stops the spinner and shows a success message.
 func (s *Spinner) Success(message string) {
+	s.succeed(message)
+	if s.bell {
+		Bell()
+	}
+}
+
+// succeed stops the spinner and prints a success line without ringing the
+// bell, which Next uses for the steps before the last.
+func (s *Spinner) succeed(message string) {
 	s.Stop()
-	fmt.Printf("\r%s %s\n", style.Success.Sprint("✓"), message)
+	fmt.Fprintf(out(), "\r%s %s\n", style.Success.Sprint("✓"), message)
 }
 
 // Error stops the spinner and shows an error message.
 func (s *Spinner) Error(message string) {
 	s.Stop()
-	fmt.Printf("\r%s %s\n", style.Error.Sprint("✗"), message)
+	fmt.Fprintf(out(), "\r%s %s\n", style.Error.Sprint("✗"), message)
 }
 
 // Warning stops the spinner and shows a warning message.
 func (s *Spinner) Warning(message string) {
 	s.Stop()
-	fmt.Printf("\r%s %s\n", style.Warning.Sprint("⚠"), message)
+	fmt.Fprintf(out(), "\r%s %s\n", style.Warning.Sprint("⚠"), message)
 }
 
 // Info stops the spinner and shows an info message.
 func (s *Spinner) Info(message string) {
 	s.Stop()
-	fmt.Printf("\r%s %s\n", style.Primary.Sprint("ℹ"), message)
+	fmt.Fprintf(out(), "\r%s %s\n", style.Primary.Sprint("ℹ"), message)
+}
+
+// Next finalizes the current step, printing its text as a success line, and
+// continues animating with text as the next step:
+//
+//	s.Start("Downloading")
+//	s.Next("Extracting")  // ✓ Downloading
+//	s.Next("Installing")  // ✓ Extracting
+//	s.Success("Installed")
+func (s *Spinner) Next(text string) {
+	s.mu.Lock()
+	previous := s.text
+	s.mu.Unlock()
+
+	s.succeed(previous)
+	s.Start(text)
 }
 
 // Update updates the spinner text without restarting the animation.
 func (s *Spinner) Update(text string) {
+	s.mu.Lock()
 	s.text = text
-}
\ No newline at end of file
+	s.mu.Unlock()
+}
@@ -0,0 +1,121 @@
+package ux
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagaking/cmdux/core"
+)
+
+func TestSpinnerTemplate(t *testing.T) {
+	s := NewSpinner(SpinnerDots).Template(func(frame, text string, elapsed time.Duration) string {
+		return fmt.Sprintf("%s %s %ds", text, frame, int(elapsed.Seconds()))
+	})
+	s.cursor = core.NewCursorGuard(io.Discard)
+	s.text = "Loading"
+	s.started = time.Now().Add(-3 * time.Second)
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+	s.drawFrame(s.stop, "⠋")
+
+	if got := core.StripANSI(buf.String()); got != "\rLoading ⠋ 3s" {
+		t.Errorf("drawn line = %q, want %q", got, "\rLoading ⠋ 3s")
+	}
+	if s.lineWidth != 12 {
+		t.Errorf("lineWidth = %d, want 12", s.lineWidth)
+	}
+}
+
+func TestAvailableSpinners(t *testing.T) {
+	styles := AvailableSpinners()
+	if len(styles) != len(spinnerFrames) {
+		t.Fatalf("got %d styles, want %d", len(styles), len(spinnerFrames))
+	}
+	for i, spinnerStyle := range styles {
+		if i > 0 && styles[i-1] >= spinnerStyle {
+			t.Errorf("styles not sorted: %v", styles)
+		}
+		if len(spinnerFrames[spinnerStyle]) == 0 {
+			t.Errorf("style %q has no frames", spinnerStyle)
+		}
+	}
+}
+
+func TestSpinnerStartContext(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	s := NewSpinner(SpinnerDots).Delay(time.Millisecond)
+	s.cursor = core.NewCursorGuard(io.Discard)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.StartContext(ctx, "Working")
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		stopped := s.stopped
+		s.mu.Unlock()
+		if stopped {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("spinner still running after the context was cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if out := buf.String(); !strings.HasSuffix(out, "\r"+strings.Repeat(" ", core.MeasureText("⠋ Working"))+"\r") {
+		t.Errorf("expected the line to be cleared, got %q", out)
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	s := NewSpinner(SpinnerDots)
+	s.Success("done")
+	NewProgressBar(10).SetTotal(2).Interactive(false).Complete("finished")
+	if got := core.StripANSI(buf.String()); got != "\r✓ done\nProgress: 100% (2/2)\n✓ finished\n" {
+		t.Errorf("output = %q", got)
+	}
+
+	SetOutput(io.Discard)
+	if outIsTerminal() {
+		t.Error("a discarded output is not a terminal")
+	}
+	SetOutput(nil)
+	if out() != os.Stdout || effectOut != os.Stdout {
+		t.Error("SetOutput(nil) should restore os.Stdout")
+	}
+}
+
+func TestSetQuietConcurrent(t *testing.T) {
+	SetOutput(io.Discard)
+	defer SetOutput(nil)
+	defer SetQuiet(false)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetQuiet(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		Bell()
+	}
+	<-done
+}